@@ -0,0 +1,4 @@
+data "mongodb_collections" "example" {
+  database = "example-account"
+  type     = "collection"
+}
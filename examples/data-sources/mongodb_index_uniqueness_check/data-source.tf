@@ -0,0 +1,5 @@
+data "mongodb_index_uniqueness_check" "example" {
+  database   = "example-account"
+  collection = "users"
+  fields     = ["email"]
+}
@@ -0,0 +1,8 @@
+data "mongodb_index_usage" "example" {
+  database   = "example-account"
+  collection = "users"
+}
+
+output "unused_indexes" {
+  value = [for i in data.mongodb_index_usage.example.indexes : i.name if i.ops == 0]
+}
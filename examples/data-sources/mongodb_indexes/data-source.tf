@@ -0,0 +1,8 @@
+data "mongodb_indexes" "example" {
+  database   = "example-account"
+  collection = "users"
+}
+
+output "unique_indexes" {
+  value = [for i in data.mongodb_indexes.example.indexes : i.name if i.unique]
+}
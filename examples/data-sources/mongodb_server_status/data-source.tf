@@ -0,0 +1,6 @@
+data "mongodb_server_status" "current" {}
+
+locals {
+  # e.g. only enable change stream pre- and post-images on MongoDB 6.0+
+  supports_pre_images = data.mongodb_server_status.current.version_array[0] >= 6
+}
@@ -0,0 +1,5 @@
+data "mongodb_users" "all" {}
+
+output "admins" {
+  value = [for u in data.mongodb_users.all.users : "${u.db}.${u.username}" if contains([for r in u.roles : r.role], "root")]
+}
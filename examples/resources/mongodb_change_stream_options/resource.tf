@@ -0,0 +1,3 @@
+resource "mongodb_change_stream_options" "example" {
+  expire_after_seconds = 3600
+}
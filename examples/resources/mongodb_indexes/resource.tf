@@ -0,0 +1,24 @@
+resource "mongodb_indexes" "users" {
+  database   = "example-account"
+  collection = "users"
+
+  index {
+    name   = "users_email"
+    unique = true
+
+    keys {
+      field = "email"
+      order = 1
+    }
+  }
+
+  index {
+    name = "users_created_at"
+    ttl  = 86400
+
+    keys {
+      field = "created_at"
+      order = 1
+    }
+  }
+}
@@ -0,0 +1,4 @@
+resource "mongodb_oplog" "example" {
+  size_mb             = 16000
+  min_retention_hours = 24
+}
@@ -0,0 +1,17 @@
+resource "mongodb_role" "reporting" {
+  database = "admin"
+  role     = "reporting"
+
+  privileges {
+    resource {
+      db         = "example-account"
+      collection = "orders"
+    }
+    actions = ["find", "listIndexes"]
+  }
+
+  inherited_roles {
+    role = "read"
+    db   = "example-account"
+  }
+}
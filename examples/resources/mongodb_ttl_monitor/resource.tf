@@ -0,0 +1,3 @@
+resource "mongodb_ttl_monitor" "example" {
+  sleep_seconds = 300
+}
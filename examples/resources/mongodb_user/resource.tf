@@ -0,0 +1,25 @@
+resource "mongodb_user" "app" {
+  database = "admin"
+  username = "app"
+  password = "change-me"
+
+  roles {
+    role = "readWrite"
+    db   = "app"
+  }
+
+  authentication_restrictions {
+    client_source = ["10.0.0.0/16"]
+  }
+}
+
+# x509 users live in $external and have no password
+resource "mongodb_user" "service" {
+  database = "$external"
+  username = "CN=service,OU=apps,O=example"
+
+  roles {
+    role = "read"
+    db   = "app"
+  }
+}
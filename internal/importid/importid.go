@@ -0,0 +1,34 @@
+// Package importid parses slash-separated import IDs.
+package importid
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Split splits id into n slash-separated parts. The last part keeps any further slashes as before.
+// Names that contain a slash can be given as %2F: only an id containing %2F (in either case) is decoded,
+// so other names with a literal "%", such as "a%b", are taken as they are. It reports false if any part
+// is missing or empty.
+func Split(id string, n int) ([]string, bool) {
+	parts := strings.SplitN(id, "/", n)
+	if len(parts) != n {
+		return nil, false
+	}
+
+	decode := strings.Contains(strings.ToUpper(id), "%2F")
+	for i, p := range parts {
+		if decode {
+			decoded, err := url.PathUnescape(p)
+			if err != nil {
+				return nil, false
+			}
+			p = decoded
+		}
+		if p == "" {
+			return nil, false
+		}
+		parts[i] = p
+	}
+	return parts, true
+}
@@ -0,0 +1,41 @@
+package importid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	cases := map[string]struct {
+		id   string
+		n    int
+		want []string
+	}{
+		"plain":                 {id: "db/coll", n: 2, want: []string{"db", "coll"}},
+		"three parts":           {id: "db/coll/idx", n: 3, want: []string{"db", "coll", "idx"}},
+		"last part keeps slash": {id: "db/a/b", n: 2, want: []string{"db", "a/b"}},
+		"encoded slash":         {id: "db/team%2Freader", n: 2, want: []string{"db", "team/reader"}},
+		"lowercase encoded":     {id: "db/team%2freader", n: 2, want: []string{"db", "team/reader"}},
+		"literal percent":       {id: "db/a%b", n: 2, want: []string{"db", "a%b"}},
+		"literal escape":        {id: "db/a%25b", n: 2, want: []string{"db", "a%25b"}},
+		"escape with slash":     {id: "db%2Fx/a%25b", n: 2, want: []string{"db/x", "a%b"}},
+		"bad escape with slash": {id: "db/a%2Fb%zz", n: 2},
+		"missing part":          {id: "db", n: 2},
+		"empty part":            {id: "db/", n: 2},
+		"empty first part":      {id: "/coll", n: 2},
+		"only a slash":          {id: "db/coll/%2F", n: 3, want: []string{"db", "coll", "/"}},
+		"missing part of three": {id: "db/coll", n: 3},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := Split(tc.id, tc.n)
+			if ok != (tc.want != nil) {
+				t.Fatalf("Split(%q, %d) ok = %t, want %t", tc.id, tc.n, ok, tc.want != nil)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("Split(%q, %d) = %q, want %q", tc.id, tc.n, got, tc.want)
+			}
+		})
+	}
+}
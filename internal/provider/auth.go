@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+// authMechanisms are the auth mechanisms selectable through the auth_mechanism attribute.
+// GSSAPI is left out: the driver only supports it when built with cgo and the gssapi tag, which releases aren't.
+var authMechanisms = []string{"SCRAM-SHA-1", "SCRAM-SHA-256", "MONGODB-X509", "PLAIN", "MONGODB-AWS", "MONGODB-OIDC"}
+
+// mechanismProperties lists the auth mechanism properties each mechanism accepts.
+var mechanismProperties = map[string][]string{
+	"MONGODB-AWS":  {"AWS_SESSION_TOKEN"},
+	"MONGODB-OIDC": {"ENVIRONMENT", "TOKEN_RESOURCE"},
+}
+
+// validateMechanismProperties checks that every property key is accepted by the auth mechanism.
+func validateMechanismProperties(mechanism string, props map[string]string) error {
+	if mechanism == "" {
+		return fmt.Errorf("auth_mechanism_properties requires auth_mechanism, or an authMechanism in the URI")
+	}
+	allowed, ok := mechanismProperties[mechanism]
+	if !ok {
+		return fmt.Errorf("auth mechanism %q does not accept auth_mechanism_properties", mechanism)
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(props)) {
+		if !slices.Contains(allowed, k) {
+			return fmt.Errorf("property %q is not valid for auth mechanism %q; expected one of %v", k, mechanism, allowed)
+		}
+	}
+	return nil
+}
+
+// validateMechanismCredentials rejects username/password combinations the mechanism can't use.
+func validateMechanismCredentials(cred options.Credential) error {
+	switch cred.AuthMechanism {
+	case "MONGODB-X509":
+		if cred.Password != "" {
+			return fmt.Errorf("MONGODB-X509 authenticates with the client certificate; password must not be set")
+		}
+	case "MONGODB-OIDC":
+		if cred.Password != "" {
+			return fmt.Errorf("MONGODB-OIDC authenticates with a token; password must not be set")
+		}
+		// Without a callback, which the provider can't configure, the driver fetches tokens from the environment
+		if cred.AuthMechanismProperties["ENVIRONMENT"] == "" {
+			return fmt.Errorf("MONGODB-OIDC requires the ENVIRONMENT auth mechanism property, e.g. 'azure' or 'gcp'")
+		}
+	case "SCRAM-SHA-1", "SCRAM-SHA-256", "PLAIN":
+		if cred.Username == "" {
+			return fmt.Errorf("auth mechanism %s requires a username", cred.AuthMechanism)
+		}
+	}
+	return nil
+}
+
+// uriAuthSource returns the authSource explicitly given in the URI. Parse errors are left to ApplyURI.
+func uriAuthSource(uri string) (string, bool) {
+	cs, err := connstring.Parse(uri)
+	if err != nil || !cs.AuthSourceSet {
+		return "", false
+	}
+	return cs.AuthSource, true
+}
+
+// credential layers the auth attributes over uriAuth, the credential parsed from the URI (e.g. its
+// authMechanism). It returns nil when neither sets any credentials, so the client connects without auth.
+func credential(ctx context.Context, config providerModel, uri, user, pass string, uriAuth *options.Credential) (*options.Credential, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var cred options.Credential
+	if uriAuth != nil {
+		cred = *uriAuth
+	}
+	setAuth := uriAuth != nil
+	if user != "" || pass != "" {
+		cred.Username = user
+		cred.Password = pass
+		setAuth = true
+	}
+	// auth_source takes precedence over the URI authSource, which in turn beats the driver default
+	uriSource, uriSourceSet := uriAuthSource(uri)
+	if uriSourceSet && cred.AuthSource == "" {
+		// A URI with only authSource carries no credentials, so the driver drops it from uriAuth
+		cred.AuthSource = uriSource
+	}
+	if v := config.AuthSource.ValueString(); v != "" {
+		if uriSourceSet && uriSource != v {
+			diags.AddAttributeWarning(
+				path.Root("auth_source"),
+				"Conflicting Auth Source",
+				fmt.Sprintf("The URI sets authSource=%q; the auth_source attribute %q takes precedence.", uriSource, v),
+			)
+		}
+		// An auth source alone is no credential; the driver rejects one without a username
+		cred.AuthSource = v
+	}
+	if v := config.AuthMechanism.ValueString(); v != "" {
+		cred.AuthMechanism = v
+		setAuth = true
+	}
+	if !config.AuthMechanismProperties.IsNull() {
+		props := map[string]string{}
+		diags.Append(config.AuthMechanismProperties.ElementsAs(ctx, &props, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if err := validateMechanismProperties(cred.AuthMechanism, props); err != nil {
+			diags.AddAttributeError(path.Root("auth_mechanism_properties"), "Invalid Auth Mechanism Properties", err.Error())
+			return nil, diags
+		}
+		cred.AuthMechanismProperties = props
+		setAuth = true
+	}
+	if err := validateMechanismCredentials(cred); err != nil {
+		diags.AddAttributeError(path.Root("auth_mechanism"), "Invalid Credentials Setup", err.Error())
+		return nil, diags
+	}
+
+	if !setAuth {
+		if !config.AuthSource.IsNull() {
+			diags.AddAttributeWarning(
+				path.Root("auth_source"),
+				"Auth Source Without Credentials",
+				"auth_source has no effect because no username, password, or auth mechanism is set.",
+			)
+		}
+		return nil, diags
+	}
+	return &cred, diags
+}
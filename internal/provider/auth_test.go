@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// providerConfig is a provider configuration with every attribute null except the given auth settings.
+func providerConfig(authSource, authMechanism string) providerModel {
+	config := providerModel{
+		AuthSource:              types.StringNull(),
+		AuthMechanism:           types.StringNull(),
+		AuthMechanismProperties: types.MapNull(types.StringType),
+	}
+	if authSource != "" {
+		config.AuthSource = types.StringValue(authSource)
+	}
+	if authMechanism != "" {
+		config.AuthMechanism = types.StringValue(authMechanism)
+	}
+	return config
+}
+
+// uriCredential returns the credential the driver parses from uri, as Configure passes it to credential.
+func uriCredential(uri string) *options.Credential {
+	return options.Client().ApplyURI(uri).Auth
+}
+
+func TestCredentialWithoutUsername(t *testing.T) {
+	cases := map[string]struct {
+		config   providerModel
+		uri      string
+		wantAuth bool
+	}{
+		"no auth settings": {
+			config: providerConfig("", ""),
+			uri:    "mongodb://localhost:27017",
+		},
+		"auth_source alone": {
+			config: providerConfig("admin", ""),
+			uri:    "mongodb://localhost:27017",
+		},
+		"URI authSource alone": {
+			config: providerConfig("", ""),
+			uri:    "mongodb://localhost:27017/?authSource=admin",
+		},
+		"auth_source with x509": {
+			config:   providerConfig("$external", "MONGODB-X509"),
+			uri:      "mongodb://localhost:27017",
+			wantAuth: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cred, diags := credential(context.Background(), tc.config, tc.uri, "", "", uriCredential(tc.uri))
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if (cred != nil) != tc.wantAuth {
+				t.Errorf("credential = %+v, want auth %t", cred, tc.wantAuth)
+			}
+		})
+	}
+}
+
+func TestAuthSourcePrecedence(t *testing.T) {
+	cases := map[string]struct {
+		uri         string
+		authSource  string
+		want        string
+		wantWarning bool
+	}{
+		"driver default": {
+			uri:  "mongodb://localhost:27017",
+			want: "",
+		},
+		"URI authSource": {
+			uri:  "mongodb://localhost:27017/?authSource=users",
+			want: "users",
+		},
+		"auth_source": {
+			uri:        "mongodb://localhost:27017",
+			authSource: "admin",
+			want:       "admin",
+		},
+		"auth_source over URI authSource": {
+			uri:         "mongodb://localhost:27017/?authSource=users",
+			authSource:  "admin",
+			want:        "admin",
+			wantWarning: true,
+		},
+		"matching auth_source and URI authSource": {
+			uri:        "mongodb://localhost:27017/?authSource=admin",
+			authSource: "admin",
+			want:       "admin",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cred, diags := credential(context.Background(), providerConfig(tc.authSource, ""), tc.uri, "alice", "secret", uriCredential(tc.uri))
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if cred == nil {
+				t.Fatal("no credential for a username and password")
+			}
+			if cred.AuthSource != tc.want {
+				t.Errorf("auth source = %q, want %q", cred.AuthSource, tc.want)
+			}
+			if got := diags.WarningsCount() > 0; got != tc.wantWarning {
+				t.Errorf("warnings = %v, want warning %t", diags.Warnings(), tc.wantWarning)
+			}
+		})
+	}
+}
+
+func TestMechanismProperties(t *testing.T) {
+	cases := map[string]struct {
+		mechanism string
+		uri       string
+		password  string
+		props     map[string]string
+		wantErr   string
+	}{
+		"AWS session token": {
+			mechanism: "MONGODB-AWS",
+			props:     map[string]string{"AWS_SESSION_TOKEN": "token"},
+		},
+		"OIDC environment": {
+			mechanism: "MONGODB-OIDC",
+			props:     map[string]string{"ENVIRONMENT": "azure", "TOKEN_RESOURCE": "api://mongodb"},
+		},
+		"OIDC mechanism from the URI": {
+			uri:   "mongodb://localhost:27017/?authMechanism=MONGODB-OIDC",
+			props: map[string]string{"ENVIRONMENT": "gcp", "TOKEN_RESOURCE": "mongodb"},
+		},
+		"OIDC without environment": {
+			mechanism: "MONGODB-OIDC",
+			props:     map[string]string{"TOKEN_RESOURCE": "api://mongodb"},
+			wantErr:   "MONGODB-OIDC requires the ENVIRONMENT auth mechanism property, e.g. 'azure' or 'gcp'",
+		},
+		"OIDC with password": {
+			mechanism: "MONGODB-OIDC",
+			password:  "secret",
+			props:     map[string]string{"ENVIRONMENT": "azure"},
+			wantErr:   "MONGODB-OIDC authenticates with a token; password must not be set",
+		},
+		"no mechanism": {
+			props:   map[string]string{"AWS_SESSION_TOKEN": "token"},
+			wantErr: "auth_mechanism_properties requires auth_mechanism, or an authMechanism in the URI",
+		},
+		"property of another mechanism": {
+			mechanism: "MONGODB-AWS",
+			props:     map[string]string{"ENVIRONMENT": "azure"},
+			wantErr:   `property "ENVIRONMENT" is not valid for auth mechanism "MONGODB-AWS"; expected one of [AWS_SESSION_TOKEN]`,
+		},
+		"mechanism without properties": {
+			mechanism: "SCRAM-SHA-256",
+			props:     map[string]string{"SERVICE_NAME": "mongodb"},
+			wantErr:   `auth mechanism "SCRAM-SHA-256" does not accept auth_mechanism_properties`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			uri := tc.uri
+			if uri == "" {
+				uri = "mongodb://localhost:27017"
+			}
+			config := providerConfig("", tc.mechanism)
+			props := map[string]attr.Value{}
+			for k, v := range tc.props {
+				props[k] = types.StringValue(v)
+			}
+			config.AuthMechanismProperties = types.MapValueMust(types.StringType, props)
+
+			cred, diags := credential(context.Background(), config, uri, "", tc.password, uriCredential(uri))
+			if tc.wantErr != "" {
+				if !diags.HasError() || diags.Errors()[0].Detail() != tc.wantErr {
+					t.Fatalf("diagnostics = %v, want error %q", diags, tc.wantErr)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if cred == nil || len(cred.AuthMechanismProperties) != len(tc.props) {
+				t.Errorf("credential = %+v, want properties %v", cred, tc.props)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// redactedFields are command fields that carry credentials and must never be logged.
+var redactedFields = map[string]bool{
+	"pwd":      true,
+	"password": true,
+	"payload":  true,
+}
+
+// newCommandMonitor logs every command the driver issues at debug level.
+func newCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			tflog.Debug(ctx, "MongoDB command started", map[string]interface{}{
+				"command_name": e.CommandName,
+				"database":     e.DatabaseName,
+				"request_id":   e.RequestID,
+				"command":      redactCommand(e.Command),
+			})
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			tflog.Debug(ctx, "MongoDB command succeeded", map[string]interface{}{
+				"command_name": e.CommandName,
+				"request_id":   e.RequestID,
+				"duration":     e.Duration.String(),
+			})
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			tflog.Debug(ctx, "MongoDB command failed", map[string]interface{}{
+				"command_name": e.CommandName,
+				"request_id":   e.RequestID,
+				"duration":     e.Duration.String(),
+				"failure":      e.Failure,
+			})
+		},
+	}
+}
+
+// redactCommand renders the command as extended JSON with credential fields masked.
+// The driver already blanks security-sensitive commands (e.g. saslStart), so those log as empty.
+func redactCommand(cmd bson.Raw) string {
+	if len(cmd) == 0 {
+		return ""
+	}
+
+	var doc bson.D
+	if err := bson.Unmarshal(cmd, &doc); err != nil {
+		return "<undecodable command>"
+	}
+	for i, e := range doc {
+		if redactedFields[e.Key] {
+			doc[i].Value = "***"
+		}
+	}
+
+	extJSON, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return "<undecodable command>"
+	}
+	return string(extJSON)
+}
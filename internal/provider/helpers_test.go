@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// configValue returns a provider configuration holding the given attributes, with the rest null.
+func configValue(t *testing.T, attrs map[string]tftypes.Value) (provider.SchemaResponse, tftypes.Value) {
+	t.Helper()
+	var schemaResp provider.SchemaResponse
+	New("test")().Schema(context.Background(), provider.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, v := range attrs {
+		if _, ok := values[name]; !ok {
+			t.Fatalf("unknown provider attribute %q", name)
+		}
+		values[name] = v
+	}
+	return schemaResp, tftypes.NewValue(typ, values)
+}
+
+// validateConfig runs the provider's config validation for the given attributes, leaving the rest null.
+// It returns the error summaries and details, joined, or "" when the configuration is valid.
+func validateConfig(t *testing.T, attrs map[string]tftypes.Value) string {
+	t.Helper()
+	ctx := context.Background()
+
+	_, value := configValue(t, attrs)
+	config, err := tfprotov6.NewDynamicValue(value.Type(), value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var errs []string
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, d.Summary+": "+d.Detail)
+		}
+	}
+	return strings.Join(errs, "; ")
+}
+
+// configure runs the provider's Configure for the given attributes, leaving the rest null, and releases any
+// client it creates. The MONGODB_* environment variables are cleared for the test.
+func configure(t *testing.T, attrs map[string]tftypes.Value) provider.ConfigureResponse {
+	t.Helper()
+	p := New("test")().(*mongodbProvider)
+	t.Cleanup(func() {
+		if p.release != nil {
+			p.release()
+		}
+	})
+	return configureProvider(t, p, attrs)
+}
+
+// configureProvider runs Configure of p for the given attributes, leaving the rest null. The MONGODB_*
+// environment variables are cleared for the test.
+func configureProvider(t *testing.T, p provider.Provider, attrs map[string]tftypes.Value) provider.ConfigureResponse {
+	t.Helper()
+	for _, env := range []string{"MONGODB_URI", "MONGODB_USERNAME", "MONGODB_PASSWORD"} {
+		t.Setenv(env, "")
+	}
+
+	schemaResp, value := configValue(t, attrs)
+	var resp provider.ConfigureResponse
+	p.Configure(context.Background(), provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: value}}, &resp)
+	return resp
+}
@@ -2,21 +2,40 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/datafy-io/terraform-provider-mongodb/internal/retry"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/service/cluster"
 	"github.com/datafy-io/terraform-provider-mongodb/internal/service/collection"
 	"github.com/datafy-io/terraform-provider-mongodb/internal/service/database"
 	"github.com/datafy-io/terraform-provider-mongodb/internal/service/index"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/service/role"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/service/user"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// compressors are the wire compressors supported by the driver.
+var compressors = []string{"zstd", "snappy", "zlib"}
+
+// defaultTimeout is used for connect and server selection when no timeout is configured.
+const defaultTimeout = 10 * time.Second
+
 // Ensure the implementation satisfies the expected interfaces.
 var _ provider.Provider = &mongodbProvider{}
 
@@ -30,12 +49,54 @@ func New(version string) func() provider.Provider {
 
 type mongodbProvider struct {
 	version string
+
+	// release disconnects the client of the last successful Configure and removes its retry policy.
+	release func()
 }
 
 type providerModel struct {
-	URI      types.String `tfsdk:"uri"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	URI           types.String `tfsdk:"uri"`
+	Username      types.String `tfsdk:"username"`
+	Password      types.String `tfsdk:"password"`
+	AuthSource    types.String `tfsdk:"auth_source"`
+	AuthMechanism types.String `tfsdk:"auth_mechanism"`
+
+	AuthMechanismProperties types.Map `tfsdk:"auth_mechanism_properties"`
+
+	TLSCAFile                     types.String `tfsdk:"tls_ca_file"`
+	TLSCertificateKeyFile         types.String `tfsdk:"tls_certificate_key_file"`
+	TLSCertificateKeyFilePassword types.String `tfsdk:"tls_certificate_key_file_password"`
+
+	TimeoutMS                     types.Int64 `tfsdk:"timeout_ms"`
+	ConnectTimeoutSeconds         types.Int64 `tfsdk:"connect_timeout_seconds"`
+	ServerSelectionTimeoutSeconds types.Int64 `tfsdk:"server_selection_timeout_seconds"`
+
+	ReadPreference      types.String `tfsdk:"read_preference"`
+	ReadPreferenceTags  types.Map    `tfsdk:"read_preference_tags"`
+	MaxStalenessSeconds types.Int64  `tfsdk:"max_staleness_seconds"`
+
+	MaxPoolSize            types.Int64 `tfsdk:"max_pool_size"`
+	MinPoolSize            types.Int64 `tfsdk:"min_pool_size"`
+	MaxConnIdleTimeSeconds types.Int64 `tfsdk:"max_conn_idle_time_seconds"`
+
+	Compressors          types.List  `tfsdk:"compressors"`
+	ZlibCompressionLevel types.Int64 `tfsdk:"zlib_compression_level"`
+
+	CommandLogging types.Bool `tfsdk:"command_logging"`
+	LazyConnect    types.Bool `tfsdk:"lazy_connect"`
+
+	DirectConnection types.Bool   `tfsdk:"direct_connection"`
+	ExpectedTopology types.String `tfsdk:"expected_topology"`
+	AppName          types.String `tfsdk:"app_name"`
+
+	URIOptions types.Map `tfsdk:"uri_options"`
+
+	ServerAPIVersion           types.String `tfsdk:"server_api_version"`
+	ServerAPIStrict            types.Bool   `tfsdk:"server_api_strict"`
+	ServerAPIDeprecationErrors types.Bool   `tfsdk:"server_api_deprecation_errors"`
+
+	RetryMaxAttempts    types.Int64 `tfsdk:"retry_max_attempts"`
+	RetryTimeoutSeconds types.Int64 `tfsdk:"retry_timeout_seconds"`
 }
 
 type providerData struct {
@@ -51,17 +112,189 @@ func (p *mongodbProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"uri": schema.StringAttribute{
-				Required:    true,
-				Description: "MongoDB URI, e.g. mongodb+srv://cluster0.x.mongodb.net",
+				Optional:    true,
+				Description: "MongoDB URI, e.g. mongodb+srv://cluster0.x.mongodb.net. Can also be set with the MONGODB_URI environment variable.",
 			},
 			"username": schema.StringAttribute{
 				Optional:    true,
-				Description: "Username; if set, SRV must not contain userinfo.",
+				Description: "Username; if set, SRV must not contain userinfo. Can also be set with the MONGODB_USERNAME environment variable.",
 			},
 			"password": schema.StringAttribute{
 				Optional:    true,
 				Sensitive:   true,
-				Description: "Password; if set, SRV must not contain userinfo.",
+				Description: "Password; if set, SRV must not contain userinfo. Can also be set with the MONGODB_PASSWORD environment variable.",
+			},
+			"auth_source": schema.StringAttribute{
+				Optional:    true,
+				Description: "Database the user is authenticated against (authSource). Defaults to the driver behavior, usually 'admin'.",
+			},
+			"auth_mechanism": schema.StringAttribute{
+				Optional:    true,
+				Description: "Authentication mechanism. One of 'SCRAM-SHA-1', 'SCRAM-SHA-256', 'MONGODB-X509', 'PLAIN', 'MONGODB-AWS', or 'MONGODB-OIDC'. With MONGODB-X509 the username is taken from the client certificate and no password may be set. MONGODB-OIDC takes tokens from the ENVIRONMENT auth mechanism property.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(authMechanisms...),
+				},
+			},
+			"auth_mechanism_properties": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional properties for the auth mechanism, e.g. AWS_SESSION_TOKEN for MONGODB-AWS or ENVIRONMENT and TOKEN_RESOURCE for MONGODB-OIDC. Requires an auth mechanism.",
+			},
+			"tls_ca_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM file with the CA certificate(s) used to verify the server certificate.",
+			},
+			"tls_certificate_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM file with the client certificate and private key for mutual TLS.",
+			},
+			"tls_certificate_key_file_password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password for an encrypted (PKCS#8) private key in tls_certificate_key_file.",
+			},
+			"timeout_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Client-side operation timeout (timeoutMS) in milliseconds applied to every operation as a single deadline, including server selection and connection establishment. When unset, the per-stage connect and server selection timeouts apply on their own.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"connect_timeout_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Timeout in seconds for establishing a connection. (Default: 10)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"server_selection_timeout_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Timeout in seconds for selecting a suitable server. (Default: 10)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"read_preference": schema.StringAttribute{
+				Optional:    true,
+				Description: "Read preference mode. One of 'primary', 'primaryPreferred', 'secondary', 'secondaryPreferred', or 'nearest'. Defaults to the URI setting, or 'primary'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(readPreferenceModes...),
+				},
+			},
+			"read_preference_tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Tag set that eligible members must match, e.g. { region = \"east\" }. Requires a non-primary read_preference.",
+				Validators: []validator.Map{
+					mapvalidator.AlsoRequires(path.MatchRoot("read_preference")),
+				},
+			},
+			"max_staleness_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum replication lag (in seconds) tolerated for secondary reads. Requires a non-primary read preference; must be at least 90.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(int64(minMaxStaleness / time.Second)),
+				},
+			},
+			"max_pool_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of connections per server in the connection pool; 0 means unlimited. (Default: 100)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_pool_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum number of connections per server kept in the connection pool. (Default: 0)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_conn_idle_time_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Seconds a pooled connection may stay idle before it is closed; 0 means no limit. (Default: 0)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"compressors": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Wire compressors to negotiate with the server, in order of preference. Supported: 'zstd', 'snappy', 'zlib'.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(compressors...)),
+				},
+			},
+			"zlib_compression_level": schema.Int64Attribute{
+				Optional:    true,
+				Description: "zlib compression level, -1 (default) through 9. Only used when 'zlib' is negotiated.",
+				Validators: []validator.Int64{
+					int64validator.Between(-1, 9),
+				},
+			},
+			"command_logging": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, logs every command sent to MongoDB at debug level (TF_LOG=DEBUG). Credentials are redacted. (Default: false)",
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum attempts for resource create, update, and delete operations that fail with a transient error, e.g. during a replica set failover; 1 disables retries. (Default: 3)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_timeout_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time in seconds after which a failing operation is no longer retried. (Default: 30)",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"lazy_connect": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the provider does not verify the connection while configuring, so plans that touch no MongoDB objects work while the cluster is unreachable. Connection errors then surface when a resource or data source is used. SRV records are still resolved up front. (Default: false)",
+			},
+			"direct_connection": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, connects only to the host in the URI and skips topology discovery, e.g. to manage a single hidden replica set member. Cannot be used with mongodb+srv:// URIs or multiple hosts.",
+			},
+			"expected_topology": schema.StringAttribute{
+				Optional:    true,
+				Description: "Deployment type the provider must be connected to: 'standalone', 'replica_set', or 'sharded'. Configuration fails on a mismatch, guarding against applying to the wrong deployment. Cannot be used with lazy_connect, which skips the check.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(topologies...),
+					stringvalidator.ConflictsWith(path.MatchRoot("lazy_connect")),
+				},
+			},
+			"app_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Application name sent to the server in the connection handshake, visible in currentOp and server logs. Defaults to the URI appName, or 'terraform-provider-mongodb/<version>'.",
+			},
+			"uri_options": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Additional connection string options appended to the URI query, e.g. { retryWrites = \"false\" }. Unknown options produce a warning; credential options must use their dedicated attributes.",
+			},
+			"server_api_version": schema.StringAttribute{
+				Optional:    true,
+				Description: "Stable API version to pin the client to. Currently only '1' is supported.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(options.ServerAPIVersion1)),
+				},
+			},
+			"server_api_strict": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the server rejects commands that are not part of the pinned Stable API version. Requires server_api_version.",
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("server_api_version")),
+				},
+			},
+			"server_api_deprecation_errors": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the server returns errors for commands deprecated in the pinned Stable API version. Requires server_api_version.",
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("server_api_version")),
+				},
 			},
 		},
 	}
@@ -76,50 +309,250 @@ func (p *mongodbProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	uri := config.URI.ValueString()
-	user := config.Username.ValueString()
-	pass := config.Password.ValueString()
+	// Configured values take precedence over the environment
+	uri := stringOrEnv(config.URI, "MONGODB_URI")
+	user := stringOrEnv(config.Username, "MONGODB_USERNAME")
+	pass := stringOrEnv(config.Password, "MONGODB_PASSWORD")
 
-	// In Configure
 	if uri == "" {
-		resp.Diagnostics.AddError("Missing URI", "The 'uri' attribute is required")
+		resp.Diagnostics.AddAttributeError(
+			path.Root("uri"),
+			"Missing URI",
+			"The 'uri' attribute or the MONGODB_URI environment variable must be set",
+		)
+		return
+	}
+	if !strings.HasPrefix(uri, "mongodb://") && !strings.HasPrefix(uri, "mongodb+srv://") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("uri"),
+			"Invalid URI Scheme",
+			"The 'uri' attribute must start with 'mongodb://' or 'mongodb+srv://'",
+		)
 		return
 	}
+	if problem := userinfoEncodingProblem(uri); problem != "" {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("uri"),
+			"URI Credentials May Not Be Percent-Encoded",
+			fmt.Sprintf("In the 'uri' attribute, %s. Special characters in credentials must be percent-encoded, or set them with the username and password attributes instead.", problem),
+		)
+	}
 	if (user != "" || pass != "") && strings.Contains(uri, "@") {
 		resp.Diagnostics.AddError("Invalid Credentials Setup", "When username/password are provided, SRV must not contain userinfo")
 		return
 	}
 
+	if !config.URIOptions.IsNull() {
+		uriOptions := map[string]string{}
+		resp.Diagnostics.Append(config.URIOptions.ElementsAs(ctx, &uriOptions, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		unknown, err := checkURIOptions(uriOptions)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("uri_options"), "Invalid URI Options", err.Error())
+			return
+		}
+		for _, k := range unknown {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("uri_options"),
+				"Unknown URI Option",
+				fmt.Sprintf("Option %q is not a known connection string option and may be ignored or rejected by the driver.", k),
+			)
+		}
+		uri = appendURIOptions(uri, uriOptions)
+	}
+
 	clientOpts := options.Client().ApplyURI(uri)
-	if user != "" || pass != "" {
-		clientOpts.SetAuth(options.Credential{
-			Username: user,
-			Password: pass,
-		})
+
+	cred, diags := credential(ctx, config, uri, user, pass, clientOpts.Auth)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if cred != nil {
+		clientOpts.SetAuth(*cred)
+	}
+	if caFile, certKeyFile := config.TLSCAFile.ValueString(), config.TLSCertificateKeyFile.ValueString(); caFile != "" || certKeyFile != "" {
+		tlsConfig, err := buildTLSConfig(caFile, certKeyFile, config.TLSCertificateKeyFilePassword.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid TLS Configuration", err.Error())
+			return
+		}
+		clientOpts.SetTLSConfig(tlsConfig)
+	}
+	if v := config.AppName.ValueString(); v != "" {
+		clientOpts.SetAppName(v)
+	} else if clientOpts.AppName == nil {
+		clientOpts.SetAppName("terraform-provider-mongodb/" + p.version)
+	}
+	if !config.DirectConnection.IsNull() {
+		if config.DirectConnection.ValueBool() && strings.HasPrefix(uri, "mongodb+srv://") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("direct_connection"),
+				"Invalid Direct Connection",
+				"direct_connection cannot be used with a mongodb+srv:// URI",
+			)
+			return
+		}
+		clientOpts.SetDirect(config.DirectConnection.ValueBool())
+	}
+	if !config.TimeoutMS.IsNull() {
+		clientOpts.SetTimeout(time.Duration(config.TimeoutMS.ValueInt64()) * time.Millisecond)
+	}
+	if v := config.ReadPreference.ValueString(); v != "" {
+		tags := map[string]string{}
+		if !config.ReadPreferenceTags.IsNull() {
+			resp.Diagnostics.Append(config.ReadPreferenceTags.ElementsAs(ctx, &tags, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		rp, err := buildReadPref(v, tags)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("read_preference"), "Invalid Read Preference", err.Error())
+			return
+		}
+		clientOpts.SetReadPreference(rp)
 	}
-	clientOpts.SetServerSelectionTimeout(10 * time.Second)
-	clientOpts.SetConnectTimeout(10 * time.Second)
+	if !config.MaxStalenessSeconds.IsNull() {
+		rp, err := withMaxStaleness(clientOpts.ReadPreference, time.Duration(config.MaxStalenessSeconds.ValueInt64())*time.Second)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("max_staleness_seconds"), "Invalid Read Preference", err.Error())
+			return
+		}
+		clientOpts.SetReadPreference(rp)
+	}
+	if v := config.ServerAPIVersion.ValueString(); v != "" {
+		serverAPI := options.ServerAPI(options.ServerAPIVersion(v))
+		if !config.ServerAPIStrict.IsNull() {
+			serverAPI.SetStrict(config.ServerAPIStrict.ValueBool())
+		}
+		if !config.ServerAPIDeprecationErrors.IsNull() {
+			serverAPI.SetDeprecationErrors(config.ServerAPIDeprecationErrors.ValueBool())
+		}
+		clientOpts.SetServerAPIOptions(serverAPI)
+	}
+	if !config.MaxPoolSize.IsNull() && !config.MinPoolSize.IsNull() &&
+		config.MaxPoolSize.ValueInt64() != 0 && config.MinPoolSize.ValueInt64() > config.MaxPoolSize.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("min_pool_size"), "Invalid Pool Size", "min_pool_size must not be greater than max_pool_size")
+		return
+	}
+	if !config.MaxPoolSize.IsNull() {
+		clientOpts.SetMaxPoolSize(uint64(config.MaxPoolSize.ValueInt64()))
+	}
+	if !config.MinPoolSize.IsNull() {
+		clientOpts.SetMinPoolSize(uint64(config.MinPoolSize.ValueInt64()))
+	}
+	if !config.MaxConnIdleTimeSeconds.IsNull() {
+		clientOpts.SetMaxConnIdleTime(time.Duration(config.MaxConnIdleTimeSeconds.ValueInt64()) * time.Second)
+	}
+	if !config.Compressors.IsNull() {
+		var names []string
+		resp.Diagnostics.Append(config.Compressors.ElementsAs(ctx, &names, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		clientOpts.SetCompressors(names)
+	}
+	if !config.ZlibCompressionLevel.IsNull() {
+		clientOpts.SetZlibLevel(int(config.ZlibCompressionLevel.ValueInt64()))
+	}
+	if config.CommandLogging.ValueBool() {
+		clientOpts.SetMonitor(newCommandMonitor())
+	}
+	serverSelectionTimeout := defaultTimeout
+	if !config.ServerSelectionTimeoutSeconds.IsNull() {
+		serverSelectionTimeout = time.Duration(config.ServerSelectionTimeoutSeconds.ValueInt64()) * time.Second
+	}
+	connectTimeout := defaultTimeout
+	if !config.ConnectTimeoutSeconds.IsNull() {
+		connectTimeout = time.Duration(config.ConnectTimeoutSeconds.ValueInt64()) * time.Second
+	}
+	clientOpts.SetServerSelectionTimeout(serverSelectionTimeout)
+	clientOpts.SetConnectTimeout(connectTimeout)
 
 	client, err := mongo.Connect(ctx, clientOpts)
 	if err != nil {
 		resp.Diagnostics.AddError("Mongo connect failed", err.Error())
 		return
 	}
+
+	retryPolicy := retry.Policy{MaxAttempts: retry.DefaultMaxAttempts, Timeout: retry.DefaultTimeout}
+	if !config.RetryMaxAttempts.IsNull() {
+		retryPolicy.MaxAttempts = int(config.RetryMaxAttempts.ValueInt64())
+	}
+	if !config.RetryTimeoutSeconds.IsNull() {
+		retryPolicy.Timeout = time.Duration(config.RetryTimeoutSeconds.ValueInt64()) * time.Second
+	}
+	unregister := retry.Register(client, retryPolicy)
+	disconnect := func() {
+		unregister()
+		_ = client.Disconnect(context.Background())
+	}
+	// Connect only starts monitoring in the background; the ping is what proves the cluster is reachable
+	if config.LazyConnect.ValueBool() {
+		p.replaceClient(disconnect)
+		resp.ResourceData = client
+		resp.DataSourceData = client
+		return
+	}
 	if err := client.Ping(ctx, nil); err != nil {
-		_ = client.Disconnect(ctx)
+		disconnect()
 		resp.Diagnostics.AddError("Mongo ping failed", err.Error())
 		return
 	}
+	if expected := config.ExpectedTopology.ValueString(); expected != "" {
+		actual, err := detectTopology(ctx, client)
+		if err != nil {
+			disconnect()
+			resp.Diagnostics.AddError("Failed to determine deployment topology", err.Error())
+			return
+		}
+		if actual != expected {
+			disconnect()
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expected_topology"),
+				"Unexpected Deployment Topology",
+				fmt.Sprintf("Expected a %s deployment, but the provider is connected to a %s deployment.", expected, actual),
+			)
+			return
+		}
+	}
 
+	p.replaceClient(disconnect)
 	resp.ResourceData = client
 	resp.DataSourceData = client
 }
 
+// replaceClient releases the client of a previous Configure, so that configuring the provider again doesn't
+// leave its connections and retry policy behind, and keeps release for the new client.
+func (p *mongodbProvider) replaceClient(release func()) {
+	if p.release != nil {
+		p.release()
+	}
+	p.release = release
+}
+
+// stringOrEnv returns the attribute value, or the environment variable when the attribute is null or empty.
+func stringOrEnv(v types.String, env string) string {
+	if s := v.ValueString(); s != "" {
+		return s
+	}
+	return os.Getenv(env)
+}
+
 func (p *mongodbProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		database.NewResource,
 		collection.NewResource,
 		index.NewResource,
+		index.NewBatchResource,
+		cluster.NewChangeStreamOptionsResource,
+		cluster.NewOplogResource,
+		cluster.NewTTLMonitorResource,
+		user.NewResource,
+		role.NewResource,
 	}
 }
 
@@ -127,6 +560,12 @@ func (p *mongodbProvider) DataSources(_ context.Context) []func() datasource.Dat
 	return []func() datasource.DataSource{
 		database.NewDataSource,
 		collection.NewDataSource,
+		collection.NewListDataSource,
 		index.NewDataSource,
+		index.NewListDataSource,
+		index.NewUniquenessCheckDataSource,
+		index.NewUsageDataSource,
+		cluster.NewServerStatusDataSource,
+		user.NewListDataSource,
 	}
 }
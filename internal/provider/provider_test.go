@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/datafy-io/terraform-provider-mongodb/internal/retry"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestExpectedTopologyConflictsWithLazyConnect(t *testing.T) {
+	cases := map[string]struct {
+		attrs   map[string]tftypes.Value
+		wantErr bool
+	}{
+		"expected_topology": {
+			attrs: map[string]tftypes.Value{"expected_topology": tftypes.NewValue(tftypes.String, "replica_set")},
+		},
+		"lazy_connect": {
+			attrs: map[string]tftypes.Value{"lazy_connect": tftypes.NewValue(tftypes.Bool, true)},
+		},
+		"both": {
+			attrs: map[string]tftypes.Value{
+				"expected_topology": tftypes.NewValue(tftypes.String, "replica_set"),
+				"lazy_connect":      tftypes.NewValue(tftypes.Bool, true),
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := validateConfig(t, tc.attrs); (err != "") != tc.wantErr {
+				t.Errorf("error = %q, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestURIScheme(t *testing.T) {
+	cases := map[string]struct {
+		uri     string
+		wantErr string
+	}{
+		"mongodb":   {uri: "mongodb://localhost:27017"},
+		"seed list": {uri: "mongodb://h1:27017,h2:27017/?replicaSet=rs0"},
+		"bare host": {uri: "localhost:27017", wantErr: "Invalid URI Scheme"},
+		"http":      {uri: "http://localhost:27017", wantErr: "Invalid URI Scheme"},
+		"uppercase": {uri: "MONGODB://localhost:27017", wantErr: "Invalid URI Scheme"},
+		"missing":   {wantErr: "Missing URI"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			attrs := map[string]tftypes.Value{"lazy_connect": tftypes.NewValue(tftypes.Bool, true)}
+			if tc.uri != "" {
+				attrs["uri"] = tftypes.NewValue(tftypes.String, tc.uri)
+			}
+			resp := configure(t, attrs)
+
+			var got string
+			if resp.Diagnostics.HasError() {
+				got = resp.Diagnostics.Errors()[0].Summary()
+			}
+			if got != tc.wantErr {
+				t.Errorf("error = %q, want %q", got, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconfigureReleasesClient(t *testing.T) {
+	p := New("test")().(*mongodbProvider)
+	attrs := map[string]tftypes.Value{
+		"uri":                tftypes.NewValue(tftypes.String, "mongodb://localhost:27017"),
+		"lazy_connect":       tftypes.NewValue(tftypes.Bool, true),
+		"retry_max_attempts": tftypes.NewValue(tftypes.Number, 7),
+	}
+
+	first := configureProvider(t, p, attrs)
+	if first.Diagnostics.HasError() {
+		t.Fatal(first.Diagnostics)
+	}
+	firstClient := first.ResourceData.(*mongo.Client)
+	if got := retry.For(firstClient).MaxAttempts; got != 7 {
+		t.Fatalf("first client max attempts = %d, want 7", got)
+	}
+
+	second := configureProvider(t, p, attrs)
+	if second.Diagnostics.HasError() {
+		t.Fatal(second.Diagnostics)
+	}
+	secondClient := second.ResourceData.(*mongo.Client)
+	if got := retry.For(firstClient).MaxAttempts; got != retry.DefaultMaxAttempts {
+		t.Errorf("replaced client keeps its policy: max attempts = %d", got)
+	}
+	if got := retry.For(secondClient).MaxAttempts; got != 7 {
+		t.Errorf("second client max attempts = %d, want 7", got)
+	}
+	if err := firstClient.Ping(context.Background(), nil); err == nil {
+		t.Error("replaced client is still connected")
+	}
+
+	p.release()
+	if got := retry.For(secondClient).MaxAttempts; got != retry.DefaultMaxAttempts {
+		t.Errorf("released client keeps its policy: max attempts = %d", got)
+	}
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// minMaxStaleness is the smallest maxStalenessSeconds the server and driver accept.
+const minMaxStaleness = 90 * time.Second
+
+// withMaxStaleness rebuilds the read preference with the given max staleness, keeping its mode and tag sets.
+func withMaxStaleness(rp *readpref.ReadPref, maxStaleness time.Duration) (*readpref.ReadPref, error) {
+	if rp == nil || rp.Mode() == readpref.PrimaryMode {
+		return nil, fmt.Errorf("max staleness cannot be combined with the primary read preference")
+	}
+
+	opts := []readpref.Option{readpref.WithMaxStaleness(maxStaleness)}
+	if tagSets := rp.TagSets(); len(tagSets) > 0 {
+		opts = append(opts, readpref.WithTagSets(tagSets...))
+	}
+	if hedge := rp.HedgeEnabled(); hedge != nil {
+		opts = append(opts, readpref.WithHedgeEnabled(*hedge))
+	}
+	return readpref.New(rp.Mode(), opts...)
+}
+
+// readPreferenceModes are the modes selectable through the read_preference attribute.
+var readPreferenceModes = []string{"primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest"}
+
+// buildReadPref creates a read preference for the mode with a single tag set built from tags.
+func buildReadPref(mode string, tags map[string]string) (*readpref.ReadPref, error) {
+	m, err := readpref.ModeFromString(mode)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return readpref.New(m)
+	}
+	if m == readpref.PrimaryMode {
+		return nil, fmt.Errorf("read preference tags cannot be combined with the primary read preference")
+	}
+
+	pairs := make([]string, 0, 2*len(tags))
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		pairs = append(pairs, k, tags[k])
+	}
+	return readpref.New(m, readpref.WithTags(pairs...))
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/youmark/pkcs8"
+)
+
+// buildTLSConfig loads the CA bundle and client certificate used to connect over (mutual) TLS.
+func buildTLSConfig(caFile, certKeyFile, certKeyPassword string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read tls_ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("tls_ca_file %s contains no PEM certificates", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certKeyFile != "" {
+		var cert tls.Certificate
+		var err error
+		if certKeyPassword == "" {
+			// The file holds both the certificate and the private key
+			cert, err = tls.LoadX509KeyPair(certKeyFile, certKeyFile)
+		} else {
+			cert, err = loadEncryptedKeyPair(certKeyFile, certKeyPassword)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("load tls_certificate_key_file: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// loadEncryptedKeyPair reads a PEM file containing certificates and a password-protected private key.
+func loadEncryptedKeyPair(file, password string) (tls.Certificate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	var cert tls.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			cert.Certificate = append(cert.Certificate, block.Bytes)
+		case "ENCRYPTED PRIVATE KEY":
+			key, err := pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(password))
+			if err != nil {
+				return tls.Certificate{}, fmt.Errorf("decrypt private key: %w", err)
+			}
+			cert.PrivateKey = key
+		}
+	}
+
+	if len(cert.Certificate) == 0 {
+		return tls.Certificate{}, fmt.Errorf("no certificate found in %s", file)
+	}
+	if cert.PrivateKey == nil {
+		return tls.Certificate{}, fmt.Errorf("no encrypted PKCS#8 private key found in %s", file)
+	}
+	return cert, nil
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	topologyStandalone = "standalone"
+	topologyReplicaSet = "replica_set"
+	topologySharded    = "sharded"
+)
+
+// topologies are the values accepted by the expected_topology attribute.
+var topologies = []string{topologyStandalone, topologyReplicaSet, topologySharded}
+
+// commandNotFoundCode is the server error code for an unknown command.
+const commandNotFoundCode = 59
+
+// detectTopology classifies the deployment from the hello response of the selected server. Servers older
+// than 4.4.2 without hello are asked with the legacy isMaster command instead.
+func detectTopology(ctx context.Context, client *mongo.Client) (string, error) {
+	var hello struct {
+		Msg     string `bson:"msg"`
+		SetName string `bson:"setName"`
+	}
+	admin := client.Database("admin")
+	err := admin.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && (cmdErr.Code == commandNotFoundCode || cmdErr.Name == "CommandNotFound") {
+		err = admin.RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&hello)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case hello.Msg == "isdbgrid":
+		return topologySharded, nil
+	case hello.SetName != "":
+		return topologyReplicaSet, nil
+	default:
+		return topologyStandalone, nil
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestDetectTopology(t *testing.T) {
+	commandNotFound := mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 59, Name: "CommandNotFound", Message: "no such command: 'hello'"})
+
+	cases := map[string]struct {
+		responses []bson.D
+		want      string
+		commands  []string
+	}{
+		"standalone": {
+			responses: []bson.D{mtest.CreateSuccessResponse(bson.E{Key: "isWritablePrimary", Value: true})},
+			want:      topologyStandalone,
+			commands:  []string{"hello"},
+		},
+		"replica set": {
+			responses: []bson.D{mtest.CreateSuccessResponse(bson.E{Key: "setName", Value: "rs0"})},
+			want:      topologyReplicaSet,
+			commands:  []string{"hello"},
+		},
+		"sharded": {
+			responses: []bson.D{mtest.CreateSuccessResponse(bson.E{Key: "msg", Value: "isdbgrid"})},
+			want:      topologySharded,
+			commands:  []string{"hello"},
+		},
+		"replica set without hello": {
+			responses: []bson.D{commandNotFound, mtest.CreateSuccessResponse(bson.E{Key: "setName", Value: "rs0"})},
+			want:      topologyReplicaSet,
+			commands:  []string{"hello", "isMaster"},
+		},
+		"sharded without hello": {
+			responses: []bson.D{commandNotFound, mtest.CreateSuccessResponse(bson.E{Key: "msg", Value: "isdbgrid"})},
+			want:      topologySharded,
+			commands:  []string{"hello", "isMaster"},
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(tc.responses...)
+			got, err := detectTopology(context.Background(), mt.Client)
+			if err != nil {
+				mt.Fatal(err)
+			}
+			if got != tc.want {
+				mt.Errorf("topology = %s, want %s", got, tc.want)
+			}
+
+			var names []string
+			for _, e := range mt.GetAllStartedEvents() {
+				names = append(names, e.CommandName)
+			}
+			if !slices.Equal(names, tc.commands) {
+				mt.Errorf("commands = %v, want %v", names, tc.commands)
+			}
+		})
+	}
+
+	mt.Run("other errors", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Name: "Unauthorized", Message: "not authorized"}))
+		if _, err := detectTopology(context.Background(), mt.Client); err == nil {
+			mt.Error("detectTopology ignored an authorization error")
+		}
+	})
+}
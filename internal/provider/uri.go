@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// userinfoEncodingProblem describes likely-unencoded characters in the userinfo of a MongoDB URI,
+// or returns "" when the userinfo looks correctly percent-encoded (or there is none).
+func userinfoEncodingProblem(uri string) string {
+	rest := uri[strings.Index(uri, "://")+len("://"):]
+	if i := strings.Index(rest, "?"); i >= 0 {
+		rest = rest[:i]
+	}
+	// The last "@" ends the userinfo; any earlier one belongs to the credentials
+	at := strings.LastIndex(rest, "@")
+	if at < 0 {
+		return ""
+	}
+	userinfo := rest[:at]
+
+	switch {
+	case strings.Contains(userinfo, "@"):
+		return "the username or password contains an unencoded '@' (use %40)"
+	case strings.Count(userinfo, ":") > 1:
+		return "the username or password contains an unencoded ':' (use %3A)"
+	case strings.Contains(userinfo, "/"):
+		return "the username or password contains an unencoded '/' (use %2F)"
+	}
+	if _, err := url.PathUnescape(userinfo); err != nil {
+		return fmt.Sprintf("the username or password contains an invalid percent-encoding (%s); encode a literal '%%' as %%25", err)
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// knownURIOptions are the connection string options understood by the Go driver, lowercased.
+var knownURIOptions = map[string]bool{
+	"appname":                              true,
+	"compressors":                          true,
+	"connecttimeoutms":                     true,
+	"directconnection":                     true,
+	"heartbeatfrequencyms":                 true,
+	"journal":                              true,
+	"loadbalanced":                         true,
+	"localthresholdms":                     true,
+	"maxconnecting":                        true,
+	"maxidletimems":                        true,
+	"maxpoolsize":                          true,
+	"maxstalenessseconds":                  true,
+	"minpoolsize":                          true,
+	"readconcernlevel":                     true,
+	"readpreference":                       true,
+	"readpreferencetags":                   true,
+	"replicaset":                           true,
+	"retryreads":                           true,
+	"retrywrites":                          true,
+	"serverselectiontimeoutms":             true,
+	"sockettimeoutms":                      true,
+	"srvmaxhosts":                          true,
+	"srvservicename":                       true,
+	"ssl":                                  true,
+	"timeoutms":                            true,
+	"tls":                                  true,
+	"tlsallowinvalidcertificates":          true,
+	"tlsallowinvalidhostnames":             true,
+	"tlscafile":                            true,
+	"tlscertificatekeyfile":                true,
+	"tlsdisableocspendpointcheck":          true,
+	"tlsinsecure":                          true,
+	"w":                                    true,
+	"wtimeoutms":                           true,
+	"zlibcompressionlevel":                 true,
+	"zstdcompressionlevel":                 true,
+	"authmechanism":                        true,
+	"authsource":                           true,
+	"gssapiservicename":                    true,
+	"tlsdisablecertificaterevocationcheck": true,
+}
+
+// credentialURIOptions carry secrets and must be set through the dedicated sensitive attributes.
+var credentialURIOptions = map[string]string{
+	"authmechanismproperties":       "auth_mechanism_properties",
+	"tlscertificatekeyfilepassword": "tls_certificate_key_file_password",
+	"username":                      "username",
+	"password":                      "password",
+}
+
+// checkURIOptions rejects credential-bearing keys and returns the keys the driver doesn't know.
+func checkURIOptions(opts map[string]string) (unknown []string, err error) {
+	for _, k := range slices.Sorted(maps.Keys(opts)) {
+		lower := strings.ToLower(k)
+		if attr, ok := credentialURIOptions[lower]; ok {
+			return nil, fmt.Errorf("option %q carries credentials; use the %q attribute instead", k, attr)
+		}
+		if !knownURIOptions[lower] {
+			unknown = append(unknown, k)
+		}
+	}
+	return unknown, nil
+}
+
+// appendURIOptions adds opts to the query string of a MongoDB connection string.
+// The URI isn't parsed with net/url because a seed list ("h1,h2:27017") is not a valid host.
+func appendURIOptions(uri string, opts map[string]string) string {
+	if len(opts) == 0 {
+		return uri
+	}
+
+	params := make([]string, 0, len(opts))
+	for _, k := range slices.Sorted(maps.Keys(opts)) {
+		params = append(params, url.QueryEscape(k)+"="+url.QueryEscape(opts[k]))
+	}
+	query := strings.Join(params, "&")
+
+	if i := strings.Index(uri, "?"); i >= 0 {
+		if i == len(uri)-1 {
+			return uri + query
+		}
+		return uri + "&" + query
+	}
+
+	// The driver requires a "/" between the hosts and the query string
+	rest := uri[strings.Index(uri, "://")+len("://"):]
+	if !strings.Contains(rest, "/") {
+		uri += "/"
+	}
+	return uri + "?" + query
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheckURIOptions(t *testing.T) {
+	cases := map[string]struct {
+		opts        map[string]string
+		wantUnknown []string
+		wantErr     bool
+	}{
+		"known options": {
+			opts: map[string]string{"retryWrites": "false", "readConcernLevel": "majority"},
+		},
+		"known option in another case": {
+			opts: map[string]string{"REPLICASET": "rs0"},
+		},
+		"unknown options": {
+			opts:        map[string]string{"zeta": "1", "alpha": "2", "w": "majority"},
+			wantUnknown: []string{"alpha", "zeta"},
+		},
+		"password": {
+			opts:    map[string]string{"password": "secret"},
+			wantErr: true,
+		},
+		"credential option in another case": {
+			opts:    map[string]string{"tlsCertificateKeyFilePassword": "secret"},
+			wantErr: true,
+		},
+		"auth mechanism properties": {
+			opts:    map[string]string{"authMechanismProperties": "AWS_SESSION_TOKEN:abc"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			unknown, err := checkURIOptions(tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tc.wantErr)
+			}
+			if !slices.Equal(unknown, tc.wantUnknown) {
+				t.Errorf("unknown = %v, want %v", unknown, tc.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestAppendURIOptions(t *testing.T) {
+	cases := map[string]struct {
+		uri  string
+		opts map[string]string
+		want string
+	}{
+		"no options": {
+			uri:  "mongodb://localhost:27017",
+			want: "mongodb://localhost:27017",
+		},
+		"no path": {
+			uri:  "mongodb://localhost:27017",
+			opts: map[string]string{"w": "majority"},
+			want: "mongodb://localhost:27017/?w=majority",
+		},
+		"path without query": {
+			uri:  "mongodb://localhost:27017/admin",
+			opts: map[string]string{"w": "majority"},
+			want: "mongodb://localhost:27017/admin?w=majority",
+		},
+		"empty query": {
+			uri:  "mongodb://localhost:27017/?",
+			opts: map[string]string{"w": "majority"},
+			want: "mongodb://localhost:27017/?w=majority",
+		},
+		"existing query": {
+			uri:  "mongodb://localhost:27017/?tls=true",
+			opts: map[string]string{"w": "majority"},
+			want: "mongodb://localhost:27017/?tls=true&w=majority",
+		},
+		"seed list": {
+			uri:  "mongodb://h1,h2:27017",
+			opts: map[string]string{"replicaSet": "rs0"},
+			want: "mongodb://h1,h2:27017/?replicaSet=rs0",
+		},
+		"srv": {
+			uri:  "mongodb+srv://cluster.example.com",
+			opts: map[string]string{"retryWrites": "false"},
+			want: "mongodb+srv://cluster.example.com/?retryWrites=false",
+		},
+		"sorted and escaped": {
+			uri:  "mongodb://localhost",
+			opts: map[string]string{"readPreferenceTags": "dc:east,rack:1", "appName": "my app"},
+			want: "mongodb://localhost/?appName=my+app&readPreferenceTags=dc%3Aeast%2Crack%3A1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := appendURIOptions(tc.uri, tc.opts); got != tc.want {
+				t.Errorf("appendURIOptions(%q) = %q, want %q", tc.uri, got, tc.want)
+			}
+		})
+	}
+}
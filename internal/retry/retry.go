@@ -0,0 +1,134 @@
+// Package retry retries MongoDB operations that failed with transient errors, such as those seen
+// while a replica set elects a new primary.
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	DefaultMaxAttempts = 3
+	DefaultTimeout     = 30 * time.Second
+
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 8 * time.Second
+)
+
+// transientCodes are server error codes raised while the topology changes.
+var transientCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	89,    // NetworkTimeout
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	9001,  // SocketException
+	10107, // NotWritablePrimary
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+// Policy bounds how often and for how long an operation is retried.
+type Policy struct {
+	MaxAttempts int
+	Timeout     time.Duration
+}
+
+var (
+	mu       sync.RWMutex
+	policies = map[*mongo.Client]Policy{}
+)
+
+// Register sets the policy used for operations on client. Each provider configuration has its own client.
+// The returned func removes the policy again and must be called when client is disconnected.
+func Register(client *mongo.Client, policy Policy) (unregister func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	policies[client] = policy
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(policies, client)
+	}
+}
+
+// For returns the policy registered for client, or the defaults.
+func For(client *mongo.Client) Policy {
+	mu.RLock()
+	defer mu.RUnlock()
+	if p, ok := policies[client]; ok {
+		return p
+	}
+	return Policy{MaxAttempts: DefaultMaxAttempts, Timeout: DefaultTimeout}
+}
+
+// Do runs fn with client's policy, retrying transient errors with exponential backoff.
+func Do(ctx context.Context, client *mongo.Client, fn func(context.Context) error) error {
+	return DoApplied(ctx, client, fn)
+}
+
+// DoApplied is Do for operations that aren't idempotent, such as creating or renaming a collection. The
+// first attempt may have been applied on the server although its reply was lost, so a retry that fails with
+// one of appliedCodes, e.g. NamespaceExists after a create, counts as success.
+func DoApplied(ctx context.Context, client *mongo.Client, fn func(context.Context) error, appliedCodes ...int) error {
+	policy := For(client)
+	deadline := time.Now().Add(policy.Timeout)
+	backoff := initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if attempt > 1 && hasCode(err, appliedCodes) {
+			return nil
+		}
+		if err == nil || !IsTransient(err) || attempt >= policy.MaxAttempts || time.Now().Add(backoff).After(deadline) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func hasCode(err error, codes []int) bool {
+	var se mongo.ServerError
+	if !errors.As(err, &se) {
+		return false
+	}
+	for _, code := range codes {
+		if se.HasErrorCode(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransient reports whether err is a network error or a server error raised during a topology change.
+func IsTransient(err error) bool {
+	if mongo.IsNetworkError(err) {
+		return true
+	}
+
+	var se mongo.ServerError
+	if !errors.As(err, &se) {
+		return false
+	}
+	if se.HasErrorLabel("RetryableWriteError") {
+		return true
+	}
+	for _, code := range transientCodes {
+		if se.HasErrorCode(code) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	primarySteppedDown = mongo.CommandError{Code: 189, Name: "PrimarySteppedDown"}
+	namespaceExists    = mongo.CommandError{Code: 48, Name: "NamespaceExists"}
+)
+
+// attempts returns an operation that fails with errs in turn and then succeeds.
+func attempts(errs ...error) (func(context.Context) error, *int) {
+	calls := 0
+	return func(context.Context) error {
+		calls++
+		if calls <= len(errs) {
+			return errs[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+func TestDoApplied(t *testing.T) {
+	cases := map[string]struct {
+		errs      []error
+		wantErr   bool
+		wantCalls int
+	}{
+		"first attempt succeeds": {wantCalls: 1},
+		"applied code on the first attempt": {
+			errs:      []error{namespaceExists},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		"applied code after a lost reply": {
+			errs:      []error{primarySteppedDown, namespaceExists},
+			wantCalls: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &mongo.Client{}
+			defer Register(client, Policy{MaxAttempts: 3, Timeout: time.Minute})()
+
+			fn, calls := attempts(tc.errs...)
+			err := DoApplied(context.Background(), client, fn, 48)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tc.wantErr)
+			}
+			if *calls != tc.wantCalls {
+				t.Errorf("calls = %d, want %d", *calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDoReturnsAppliedCodes(t *testing.T) {
+	client := &mongo.Client{}
+	defer Register(client, Policy{MaxAttempts: 3, Timeout: time.Minute})()
+
+	fn, _ := attempts(primarySteppedDown, namespaceExists)
+	if err := Do(context.Background(), client, fn); err == nil {
+		t.Error("Do treated NamespaceExists as success without it being listed")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	client := &mongo.Client{}
+	policy := Policy{MaxAttempts: 7, Timeout: time.Second}
+
+	unregister := Register(client, policy)
+	if got := For(client); got != policy {
+		t.Errorf("For = %+v, want %+v", got, policy)
+	}
+
+	unregister()
+	if _, ok := policies[client]; ok {
+		t.Error("policy still registered after unregister")
+	}
+	if got, want := For(client), (Policy{MaxAttempts: DefaultMaxAttempts, Timeout: DefaultTimeout}); got != want {
+		t.Errorf("For = %+v, want the defaults %+v", got, want)
+	}
+}
@@ -0,0 +1,219 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	changeStreamOptionsParameter = "changeStreamOptions"
+
+	// previousExpiryKey is the private state key holding the expiry to restore on delete.
+	previousExpiryKey = "previous_expire_after_seconds"
+)
+
+var _ resource.Resource = &ChangeStreamOptionsResource{}
+var _ resource.ResourceWithConfigure = &ChangeStreamOptionsResource{}
+var _ resource.ResourceWithImportState = &ChangeStreamOptionsResource{}
+
+func NewChangeStreamOptionsResource() resource.Resource {
+	return &ChangeStreamOptionsResource{}
+}
+
+type ChangeStreamOptionsResource struct {
+	client *mongo.Client
+}
+
+type ChangeStreamOptionsResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ExpireAfterSeconds types.Int64  `tfsdk:"expire_after_seconds"`
+}
+
+func (r *ChangeStreamOptionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_change_stream_options"
+}
+
+func (r *ChangeStreamOptionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the cluster-wide changeStreamOptions parameter. On destroy, the previous setting is restored.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Required:    true,
+				Description: "Retention (in seconds) of change stream pre- and post-images (preAndPostImages.expireAfterSeconds).",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *ChangeStreamOptionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ChangeStreamOptionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ChangeStreamOptionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, err := r.getExpiry(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("getClusterParameter failed", err.Error())
+		return
+	}
+	previousJSON, err := bson.MarshalExtJSON(bson.D{{Key: "value", Value: previous}}, false, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to record previous change stream options", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, previousExpiryKey, previousJSON)...)
+
+	if err := r.setExpiry(ctx, plan.ExpireAfterSeconds.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("setClusterParameter failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(changeStreamOptionsParameter)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ChangeStreamOptionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ChangeStreamOptionsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expiry, err := r.getExpiry(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("getClusterParameter failed", err.Error())
+		return
+	}
+
+	// "off" means images never expire by time; report it as no managed value
+	if v, ok := expiry.(int64); ok {
+		state.ExpireAfterSeconds = types.Int64Value(v)
+	} else {
+		state.ExpireAfterSeconds = types.Int64Null()
+	}
+
+	state.ID = types.StringValue(changeStreamOptionsParameter)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ChangeStreamOptionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ChangeStreamOptionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setExpiry(ctx, plan.ExpireAfterSeconds.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("setClusterParameter failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(changeStreamOptionsParameter)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ChangeStreamOptionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Restore the setting found before Terraform took over, or the server default
+	var previous interface{} = "off"
+
+	previousJSON, diags := req.Private.GetKey(ctx, previousExpiryKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(previousJSON) > 0 {
+		var doc struct {
+			Value interface{} `bson:"value"`
+		}
+		if err := bson.UnmarshalExtJSON(previousJSON, false, &doc); err != nil {
+			resp.Diagnostics.AddError("Failed to decode previous change stream options", err.Error())
+			return
+		}
+		if doc.Value != nil {
+			previous = doc.Value
+		}
+	}
+
+	if err := r.setExpiry(ctx, previous); err != nil {
+		resp.Diagnostics.AddError("setClusterParameter failed", err.Error())
+	}
+}
+
+func (r *ChangeStreamOptionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// getExpiry returns preAndPostImages.expireAfterSeconds as an int64, or the string "off".
+func (r *ChangeStreamOptionsResource) getExpiry(ctx context.Context) (interface{}, error) {
+	var result struct {
+		ClusterParameters []struct {
+			PreAndPostImages struct {
+				ExpireAfterSeconds bson.RawValue `bson:"expireAfterSeconds"`
+			} `bson:"preAndPostImages"`
+		} `bson:"clusterParameters"`
+	}
+
+	cmd := bson.D{{Key: "getClusterParameter", Value: changeStreamOptionsParameter}}
+	if err := r.client.Database("admin").RunCommand(ctx, cmd).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.ClusterParameters) == 0 {
+		return "off", nil
+	}
+
+	expiry := result.ClusterParameters[0].PreAndPostImages.ExpireAfterSeconds
+	if v, ok := expiry.AsInt64OK(); ok {
+		return v, nil
+	}
+	return "off", nil
+}
+
+func (r *ChangeStreamOptionsResource) setExpiry(ctx context.Context, expireAfterSeconds interface{}) error {
+	cmd := bson.D{{Key: "setClusterParameter", Value: bson.D{
+		{Key: changeStreamOptionsParameter, Value: bson.D{
+			{Key: "preAndPostImages", Value: bson.D{
+				{Key: "expireAfterSeconds", Value: expireAfterSeconds},
+			}},
+		}},
+	}}}
+	return r.client.Database("admin").RunCommand(ctx, cmd).Err()
+}
@@ -0,0 +1,129 @@
+package cluster
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+const changeStreamOptionsType = "mongodb_change_stream_options"
+
+// clusterParameterResponse is a mocked getClusterParameter reply for changeStreamOptions with the given expiry.
+func clusterParameterResponse(expireAfterSeconds any) bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "clusterParameters", Value: bson.A{bson.D{
+		{Key: "_id", Value: changeStreamOptionsParameter},
+		{Key: "preAndPostImages", Value: bson.D{{Key: "expireAfterSeconds", Value: expireAfterSeconds}}},
+	}}})
+}
+
+// setExpiries returns the expireAfterSeconds values sent with setClusterParameter, in order.
+func setExpiries(mt *mtest.T) []bson.RawValue {
+	var values []bson.RawValue
+	for _, cmd := range startedCommands(mt, "setClusterParameter") {
+		values = append(values, cmd.Lookup("setClusterParameter", changeStreamOptionsParameter, "preAndPostImages", "expireAfterSeconds"))
+	}
+	return values
+}
+
+func TestChangeStreamOptionsRestoresPrevious(t *testing.T) {
+	r := NewChangeStreamOptionsResource()
+	model := &ChangeStreamOptionsResourceModel{ID: types.StringUnknown(), ExpireAfterSeconds: types.Int64Value(3600)}
+
+	cases := map[string]struct {
+		previous any
+		want     any
+	}{
+		"previous expiry": {
+			previous: int64(600),
+			want:     int64(600),
+		},
+		"previously off": {
+			previous: "off",
+			want:     "off",
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(clusterParameterResponse(tc.previous), mtest.CreateSuccessResponse())
+			created := applyChange(mt.T, mt, changeStreamOptionsType, r, nil, model, nil)
+
+			if got := commandNames(mt); !slices.Equal(got, []string{"getClusterParameter", "setClusterParameter"}) {
+				mt.Fatalf("create commands = %v", got)
+			}
+			if got, ok := setExpiries(mt)[0].AsInt64OK(); !ok || got != 3600 {
+				mt.Errorf("created with expireAfterSeconds %s, want 3600", setExpiries(mt)[0])
+			}
+			var state ChangeStreamOptionsResourceModel
+			newStateOf(mt.T, r, created, &state)
+			if state.ID.ValueString() != changeStreamOptionsParameter {
+				mt.Errorf("id = %s", state.ID)
+			}
+
+			mt.ClearEvents()
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+			applyChange(mt.T, mt, changeStreamOptionsType, r, &state, nil, created.Private)
+
+			restored := setExpiries(mt)
+			if len(restored) != 1 {
+				mt.Fatalf("delete commands = %v, want one setClusterParameter", commandNames(mt))
+			}
+			if want, ok := tc.want.(int64); ok {
+				if got, ok := restored[0].AsInt64OK(); !ok || got != want {
+					mt.Errorf("restored %s, want %d", restored[0], want)
+				}
+			} else if got, ok := restored[0].StringValueOK(); !ok || got != tc.want {
+				mt.Errorf("restored %s, want %q", restored[0], tc.want)
+			}
+		})
+	}
+
+	mt.Run("delete after import", func(mt *mtest.T) {
+		// An imported resource has no recorded previous setting, so the server default is restored
+		state := &ChangeStreamOptionsResourceModel{ID: types.StringValue(changeStreamOptionsParameter), ExpireAfterSeconds: types.Int64Value(3600)}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		applyChange(mt.T, mt, changeStreamOptionsType, r, state, nil, nil)
+
+		restored := setExpiries(mt)
+		if len(restored) != 1 || restored[0].StringValue() != "off" {
+			mt.Errorf("restored %v, want off", restored)
+		}
+	})
+}
+
+func TestChangeStreamOptionsRead(t *testing.T) {
+	cases := map[string]struct {
+		expiry any
+		want   types.Int64
+	}{
+		"expiry": {
+			expiry: int32(7200),
+			want:   types.Int64Value(7200),
+		},
+		"off": {
+			expiry: "off",
+			want:   types.Int64Null(),
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(clusterParameterResponse(tc.expiry))
+			prior := ChangeStreamOptionsResourceModel{ID: types.StringValue(changeStreamOptionsParameter), ExpireAfterSeconds: types.Int64Value(3600)}
+
+			var state ChangeStreamOptionsResourceModel
+			readResource(mt.T, mt, &ChangeStreamOptionsResource{}, &prior, &state)
+			if !state.ExpireAfterSeconds.Equal(tc.want) {
+				mt.Errorf("expire_after_seconds = %s, want %s", state.ExpireAfterSeconds, tc.want)
+			}
+			if got := startedCommands(mt, "getClusterParameter"); len(got) != 1 || got[0].Lookup("getClusterParameter").StringValue() != changeStreamOptionsParameter {
+				mt.Errorf("getClusterParameter = %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func newMockTest(t *testing.T) *mtest.T {
+	return mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+}
+
+// testProvider serves the cluster resources with a mocked client, so tests run through the framework and its
+// private state handling.
+type testProvider struct {
+	client *mongo.Client
+}
+
+func (p *testProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "mongodb"
+}
+
+func (p *testProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{}
+}
+
+func (p *testProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.ResourceData = p.client
+	resp.DataSourceData = p.client
+}
+
+func (p *testProvider) Resources(context.Context) []func() resource.Resource {
+	return []func() resource.Resource{NewOplogResource, NewChangeStreamOptionsResource, NewTTLMonitorResource}
+}
+
+func (p *testProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{NewServerStatusDataSource}
+}
+
+// newServer returns a protocol server for the cluster resources, configured with the mocked client.
+func newServer(t *testing.T, mt *mtest.T) tfprotov6.ProviderServer {
+	t.Helper()
+	server, err := providerserver.NewProtocol6WithError(&testProvider{client: mt.Client})()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	empty := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{})
+	config, err := tfprotov6.NewDynamicValue(empty.Type(), empty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("configure: %s: %s", d.Summary, d.Detail)
+	}
+	return server
+}
+
+// resourceSchema returns the schema of the resource r.
+func resourceSchema(r resource.Resource) tfsdk.State {
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	return tfsdk.State{Schema: resp.Schema, Raw: tftypes.NewValue(resp.Schema.Type().TerraformType(context.Background()), nil)}
+}
+
+// dynamicValue encodes model as a value of the resource r, or a null value when model is nil.
+func dynamicValue(t *testing.T, r resource.Resource, model any) *tfprotov6.DynamicValue {
+	t.Helper()
+	state := resourceSchema(r)
+	if model != nil {
+		if diags := state.Set(context.Background(), model); diags.HasError() {
+			t.Fatalf("set state: %v", diags)
+		}
+	}
+	v, err := tfprotov6.NewDynamicValue(state.Raw.Type(), state.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &v
+}
+
+// applyChange applies the change from prior to planned to the resource r, which is served as typeName. A nil
+// prior creates the resource and a nil planned destroys it; private is the private state stored with prior.
+// It returns the response, failing the test on error diagnostics.
+func applyChange(t *testing.T, mt *mtest.T, typeName string, r resource.Resource, prior, planned any, private []byte) *tfprotov6.ApplyResourceChangeResponse {
+	t.Helper()
+	req := &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     dynamicValue(t, r, prior),
+		PlannedState:   dynamicValue(t, r, planned),
+		Config:         dynamicValue(t, r, planned),
+		PlannedPrivate: private,
+	}
+	resp, err := newServer(t, mt).ApplyResourceChange(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("apply: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	return resp
+}
+
+// newStateOf decodes the new state of an applied change into target.
+func newStateOf(t *testing.T, r resource.Resource, resp *tfprotov6.ApplyResourceChangeResponse, target any) {
+	t.Helper()
+	state := resourceSchema(r)
+	raw, err := resp.NewState.Unmarshal(state.Raw.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.Raw = raw
+	if diags := state.Get(context.Background(), target); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+}
+
+// readResource runs the Read of the resource r, configured with the mocked client, from prior and decodes the
+// new state into target.
+func readResource(t *testing.T, mt *mtest.T, r resource.ResourceWithConfigure, prior, target any) {
+	t.Helper()
+	ctx := context.Background()
+	var configureResp resource.ConfigureResponse
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: mt.Client}, &configureResp)
+
+	state := resourceSchema(r)
+	if diags := state.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("set state: %v", diags)
+	}
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read: %v", resp.Diagnostics)
+	}
+	if diags := resp.State.Get(ctx, target); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+}
+
+// startedCommands returns the started commands with the given name, in order.
+func startedCommands(mt *mtest.T, name string) []bson.Raw {
+	var cmds []bson.Raw
+	for _, e := range mt.GetAllStartedEvents() {
+		if e.CommandName == name {
+			cmds = append(cmds, e.Command)
+		}
+	}
+	return cmds
+}
+
+// commandNames returns the names of the commands started against the mocked server, in order.
+func commandNames(mt *mtest.T) []string {
+	var names []string
+	for _, e := range mt.GetAllStartedEvents() {
+		names = append(names, e.CommandName)
+	}
+	return names
+}
@@ -0,0 +1,178 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	oplogID = "oplog"
+
+	// minOplogSizeMB is the smallest oplog size replSetResizeOplog accepts.
+	minOplogSizeMB = 990
+)
+
+var _ resource.Resource = &OplogResource{}
+var _ resource.ResourceWithConfigure = &OplogResource{}
+var _ resource.ResourceWithImportState = &OplogResource{}
+
+func NewOplogResource() resource.Resource {
+	return &OplogResource{}
+}
+
+type OplogResource struct {
+	client *mongo.Client
+}
+
+type OplogResourceModel struct {
+	ID                types.String  `tfsdk:"id"`
+	SizeMB            types.Int64   `tfsdk:"size_mb"`
+	MinRetentionHours types.Float64 `tfsdk:"min_retention_hours"`
+}
+
+func (r *OplogResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oplog"
+}
+
+func (r *OplogResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the replica set oplog size and minimum retention of the connected member via replSetResizeOplog. Destroying this resource leaves the oplog unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size_mb": schema.Int64Attribute{
+				Required:    true,
+				Description: fmt.Sprintf("Maximum oplog size in megabytes. Must be at least %d.", minOplogSizeMB),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(minOplogSizeMB),
+				},
+			},
+			"min_retention_hours": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Minimum number of hours to retain oplog entries (0 disables time-based retention).",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+func (r *OplogResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OplogResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan OplogResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.resize(ctx, plan); err != nil {
+		resp.Diagnostics.AddError("replSetResizeOplog failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(oplogID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *OplogResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state OplogResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stats struct {
+		MaxSize int64 `bson:"maxSize"`
+	}
+	if err := r.client.Database("local").RunCommand(ctx, bson.D{{Key: "collStats", Value: "oplog.rs"}}).Decode(&stats); err != nil {
+		resp.Diagnostics.AddError("Failed to read oplog size", err.Error())
+		return
+	}
+	state.SizeMB = types.Int64Value(stats.MaxSize / (1024 * 1024))
+
+	var status struct {
+		OplogTruncation struct {
+			MinRetentionHours *float64 `bson:"oplogMinRetentionHours"`
+		} `bson:"oplogTruncation"`
+	}
+	if err := r.client.Database("admin").RunCommand(ctx, bson.D{{Key: "serverStatus", Value: 1}}).Decode(&status); err != nil {
+		resp.Diagnostics.AddError("Failed to read oplog retention", err.Error())
+		return
+	}
+	// Only track retention when it is managed, as the server reports 0 when unset
+	if v := status.OplogTruncation.MinRetentionHours; v != nil && (*v != 0 || !state.MinRetentionHours.IsNull()) {
+		state.MinRetentionHours = types.Float64Value(*v)
+	}
+
+	state.ID = types.StringValue(oplogID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *OplogResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan OplogResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.resize(ctx, plan); err != nil {
+		resp.Diagnostics.AddError("replSetResizeOplog failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(oplogID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *OplogResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// The oplog can't be removed; forgetting it from state is all there is to do.
+}
+
+func (r *OplogResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *OplogResource) resize(ctx context.Context, plan OplogResourceModel) error {
+	cmd := bson.D{
+		{Key: "replSetResizeOplog", Value: 1},
+		{Key: "size", Value: float64(plan.SizeMB.ValueInt64())},
+	}
+	if !plan.MinRetentionHours.IsNull() {
+		cmd = append(cmd, bson.E{Key: "minRetentionHours", Value: plan.MinRetentionHours.ValueFloat64()})
+	}
+	return r.client.Database("admin").RunCommand(ctx, cmd).Err()
+}
@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+const oplogType = "mongodb_oplog"
+
+func TestOplogResize(t *testing.T) {
+	r := NewOplogResource()
+	cases := map[string]struct {
+		retention     types.Float64
+		wantRetention bool
+	}{
+		"size only": {
+			retention: types.Float64Null(),
+		},
+		"with retention": {
+			retention:     types.Float64Value(24.5),
+			wantRetention: true,
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+			plan := &OplogResourceModel{ID: types.StringUnknown(), SizeMB: types.Int64Value(16000), MinRetentionHours: tc.retention}
+			created := applyChange(mt.T, mt, oplogType, r, nil, plan, nil)
+
+			cmds := startedCommands(mt, "replSetResizeOplog")
+			if len(cmds) != 1 {
+				mt.Fatalf("commands = %v, want one replSetResizeOplog", commandNames(mt))
+			}
+			if db := cmds[0].Lookup("$db").StringValue(); db != "admin" {
+				mt.Errorf("ran on %s, want admin", db)
+			}
+			if size, ok := cmds[0].Lookup("size").DoubleOK(); !ok || size != 16000 {
+				mt.Errorf("size = %s, want 16000.0", cmds[0].Lookup("size"))
+			}
+			retention, ok := cmds[0].Lookup("minRetentionHours").DoubleOK()
+			if ok != tc.wantRetention || (ok && retention != 24.5) {
+				mt.Errorf("minRetentionHours = %s, want set %t", cmds[0].Lookup("minRetentionHours"), tc.wantRetention)
+			}
+
+			var state OplogResourceModel
+			newStateOf(mt.T, r, created, &state)
+			if state.ID.ValueString() != oplogID {
+				mt.Errorf("id = %s", state.ID)
+			}
+		})
+	}
+
+	mt.Run("delete leaves the oplog", func(mt *mtest.T) {
+		state := &OplogResourceModel{ID: types.StringValue(oplogID), SizeMB: types.Int64Value(16000), MinRetentionHours: types.Float64Null()}
+		applyChange(mt.T, mt, oplogType, r, state, nil, nil)
+		if got := commandNames(mt); len(got) != 0 {
+			mt.Errorf("delete ran %v", got)
+		}
+	})
+}
+
+func TestOplogRead(t *testing.T) {
+	cases := map[string]struct {
+		prior     types.Float64
+		retention float64
+		want      types.Float64
+	}{
+		"unmanaged retention left null": {
+			prior: types.Float64Null(),
+			want:  types.Float64Null(),
+		},
+		"retention set outside Terraform": {
+			prior:     types.Float64Null(),
+			retention: 12,
+			want:      types.Float64Value(12),
+		},
+		"managed retention removed": {
+			prior: types.Float64Value(12),
+			want:  types.Float64Value(0),
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateSuccessResponse(bson.E{Key: "maxSize", Value: int64(2048) * 1024 * 1024}),
+				mtest.CreateSuccessResponse(bson.E{Key: "oplogTruncation", Value: bson.D{{Key: "oplogMinRetentionHours", Value: tc.retention}}}),
+			)
+			prior := OplogResourceModel{ID: types.StringValue(oplogID), SizeMB: types.Int64Value(990), MinRetentionHours: tc.prior}
+
+			var state OplogResourceModel
+			readResource(mt.T, mt, &OplogResource{}, &prior, &state)
+			if got := state.SizeMB.ValueInt64(); got != 2048 {
+				mt.Errorf("size_mb = %d, want 2048", got)
+			}
+			if !state.MinRetentionHours.Equal(tc.want) {
+				mt.Errorf("min_retention_hours = %s, want %s", state.MinRetentionHours, tc.want)
+			}
+
+			stats := startedCommands(mt, "collStats")
+			if len(stats) != 1 || stats[0].Lookup("collStats").StringValue() != "oplog.rs" || stats[0].Lookup("$db").StringValue() != "local" {
+				mt.Errorf("collStats = %v, want local.oplog.rs", stats)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerStatusDataSource{}
+var _ datasource.DataSourceWithConfigure = &ServerStatusDataSource{}
+
+func NewServerStatusDataSource() datasource.DataSource {
+	return &ServerStatusDataSource{}
+}
+
+type ServerStatusDataSource struct {
+	client *mongo.Client
+}
+
+type ServerStatusDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Version           types.String `tfsdk:"version"`
+	VersionArray      types.List   `tfsdk:"version_array"`
+	GitVersion        types.String `tfsdk:"git_version"`
+	MaxBSONObjectSize types.Int64  `tfsdk:"max_bson_object_size"`
+	UptimeSeconds     types.Int64  `tfsdk:"uptime_seconds"`
+	StorageEngine     types.String `tfsdk:"storage_engine"`
+}
+
+// buildInfo holds the buildInfo fields the data source exposes.
+type buildInfo struct {
+	Version           string  `bson:"version"`
+	VersionArray      []int64 `bson:"versionArray"`
+	GitVersion        string  `bson:"gitVersion"`
+	MaxBSONObjectSize int64   `bson:"maxBsonObjectSize"`
+}
+
+// serverStatus holds the serverStatus fields the data source exposes.
+type serverStatus struct {
+	Host          string  `bson:"host"`
+	Uptime        float64 `bson:"uptime"`
+	StorageEngine struct {
+		Name string `bson:"name"`
+	} `bson:"storageEngine"`
+}
+
+func (d *ServerStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_status"
+}
+
+func (d *ServerStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports build and runtime facts about the connected server from buildInfo and serverStatus, e.g. to gate features on the MongoDB version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Host the facts were read from.",
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Server version, e.g. '7.0.12'.",
+			},
+			"version_array": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Server version as numbers, e.g. [7, 0, 12, 0], for comparisons.",
+			},
+			"git_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Git commit the server was built from.",
+			},
+			"max_bson_object_size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Largest BSON document the server accepts, in bytes.",
+			},
+			"uptime_seconds": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Seconds since the server process started.",
+			},
+			"storage_engine": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the storage engine, e.g. 'wiredTiger'. Null on mongos.",
+			},
+		},
+	}
+}
+
+func (d *ServerStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ServerStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ServerStatusDataSourceModel
+	admin := d.client.Database("admin")
+
+	var build buildInfo
+	if err := admin.RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&build); err != nil {
+		resp.Diagnostics.AddError("Failed to run buildInfo", err.Error())
+		return
+	}
+
+	var status serverStatus
+	if err := admin.RunCommand(ctx, bson.D{{Key: "serverStatus", Value: 1}}).Decode(&status); err != nil {
+		resp.Diagnostics.AddError("Failed to run serverStatus", err.Error())
+		return
+	}
+
+	versionArray, diags := types.ListValueFrom(ctx, types.Int64Type, build.VersionArray)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringValue(status.Host)
+	state.Version = types.StringValue(build.Version)
+	state.VersionArray = versionArray
+	state.GitVersion = types.StringValue(build.GitVersion)
+	state.MaxBSONObjectSize = types.Int64Value(build.MaxBSONObjectSize)
+	state.UptimeSeconds = types.Int64Value(int64(status.Uptime))
+	state.StorageEngine = types.StringNull()
+	if status.StorageEngine.Name != "" {
+		state.StorageEngine = types.StringValue(status.StorageEngine.Name)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
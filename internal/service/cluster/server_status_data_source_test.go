@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// readServerStatus runs the server status data source Read and returns the resulting state and diagnostics.
+func readServerStatus(t *testing.T, mt *mtest.T) (ServerStatusDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	(&ServerStatusDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	config := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config}}
+	(&ServerStatusDataSource{client: mt.Client}).Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config}}, &resp)
+	if resp.Diagnostics.HasError() {
+		return ServerStatusDataSourceModel{}, resp.Diagnostics
+	}
+
+	var model ServerStatusDataSourceModel
+	if diags := resp.State.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+	return model, nil
+}
+
+func TestServerStatus(t *testing.T) {
+	mt := newMockTest(t)
+
+	mt.Run("facts", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(
+				bson.E{Key: "version", Value: "7.0.4"},
+				bson.E{Key: "versionArray", Value: bson.A{int32(7), int32(0), int32(4), int32(0)}},
+				bson.E{Key: "gitVersion", Value: "38f3e37057a43d2e9f41a39142681a76062d582e"},
+				bson.E{Key: "maxBsonObjectSize", Value: int32(16777216)},
+			),
+			mtest.CreateSuccessResponse(
+				bson.E{Key: "host", Value: "db-0:27017"},
+				bson.E{Key: "uptime", Value: 3600.7},
+				bson.E{Key: "storageEngine", Value: bson.D{{Key: "name", Value: "wiredTiger"}}},
+			),
+		)
+
+		model, diags := readServerStatus(mt.T, mt)
+		if diags.HasError() {
+			mt.Fatalf("read: %v", diags)
+		}
+		if got := commandNames(mt); !slices.Equal(got, []string{"buildInfo", "serverStatus"}) {
+			mt.Errorf("commands = %v, want buildInfo then serverStatus", got)
+		}
+		for _, cmd := range mt.GetAllStartedEvents() {
+			if db := cmd.Command.Lookup("$db").StringValue(); db != "admin" {
+				mt.Errorf("%s ran on %s, want admin", cmd.CommandName, db)
+			}
+		}
+
+		var versionArray []int64
+		if diags := model.VersionArray.ElementsAs(context.Background(), &versionArray, false); diags.HasError() {
+			mt.Fatalf("version_array: %v", diags)
+		}
+		if !slices.Equal(versionArray, []int64{7, 0, 4, 0}) {
+			mt.Errorf("version_array = %v", versionArray)
+		}
+		want := ServerStatusDataSourceModel{
+			ID:                types.StringValue("db-0:27017"),
+			Version:           types.StringValue("7.0.4"),
+			VersionArray:      model.VersionArray,
+			GitVersion:        types.StringValue("38f3e37057a43d2e9f41a39142681a76062d582e"),
+			MaxBSONObjectSize: types.Int64Value(16777216),
+			UptimeSeconds:     types.Int64Value(3600),
+			StorageEngine:     types.StringValue("wiredTiger"),
+		}
+		if !reflect.DeepEqual(model, want) {
+			mt.Errorf("state = %+v, want %+v", model, want)
+		}
+	})
+
+	mt.Run("no storage engine", func(mt *mtest.T) {
+		// mongos reports no storageEngine
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "version", Value: "7.0.4"}, bson.E{Key: "versionArray", Value: bson.A{}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "host", Value: "router:27017"}),
+		)
+
+		model, diags := readServerStatus(mt.T, mt)
+		if diags.HasError() {
+			mt.Fatalf("read: %v", diags)
+		}
+		if !model.StorageEngine.IsNull() {
+			mt.Errorf("storage_engine = %s, want null", model.StorageEngine)
+		}
+	})
+
+	mt.Run("serverStatus error", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "version", Value: "7.0.4"}),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Name: "Unauthorized", Message: "not authorized"}),
+		)
+
+		_, diags := readServerStatus(mt.T, mt)
+		if !diags.HasError() || diags[0].Summary() != "Failed to run serverStatus" {
+			mt.Errorf("diagnostics = %v, want serverStatus failure", diags)
+		}
+	})
+}
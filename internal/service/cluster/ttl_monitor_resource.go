@@ -0,0 +1,191 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	ttlMonitorSleepParameter = "ttlMonitorSleepSecs"
+
+	// defaultTTLMonitorSleepSecs is the server default, restored when no previous value was recorded.
+	defaultTTLMonitorSleepSecs = 60
+
+	// previousSleepKey is the private state key holding the interval to restore on delete.
+	previousSleepKey = "previous_sleep_seconds"
+)
+
+var _ resource.Resource = &TTLMonitorResource{}
+var _ resource.ResourceWithConfigure = &TTLMonitorResource{}
+var _ resource.ResourceWithImportState = &TTLMonitorResource{}
+
+func NewTTLMonitorResource() resource.Resource {
+	return &TTLMonitorResource{}
+}
+
+type TTLMonitorResource struct {
+	client *mongo.Client
+}
+
+type TTLMonitorResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	SleepSeconds types.Int64  `tfsdk:"sleep_seconds"`
+}
+
+func (r *TTLMonitorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ttl_monitor"
+}
+
+func (r *TTLMonitorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages how often the TTL monitor deletes expired documents (ttlMonitorSleepSecs) on the connected server. On destroy, the previous setting is restored.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sleep_seconds": schema.Int64Attribute{
+				Required:    true,
+				Description: "Seconds the TTL monitor sleeps between deletion passes.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *TTLMonitorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TTLMonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TTLMonitorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, err := r.getSleep(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("getParameter failed", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, previousSleepKey, []byte(strconv.FormatInt(previous, 10)))...)
+
+	if err := r.setSleep(ctx, plan.SleepSeconds.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("setParameter failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(ttlMonitorSleepParameter)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TTLMonitorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TTLMonitorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sleep, err := r.getSleep(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("getParameter failed", err.Error())
+		return
+	}
+
+	state.SleepSeconds = types.Int64Value(sleep)
+	state.ID = types.StringValue(ttlMonitorSleepParameter)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *TTLMonitorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TTLMonitorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setSleep(ctx, plan.SleepSeconds.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("setParameter failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(ttlMonitorSleepParameter)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TTLMonitorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Restore the setting found before Terraform took over, or the server default
+	previous := int64(defaultTTLMonitorSleepSecs)
+
+	previousValue, diags := req.Private.GetKey(ctx, previousSleepKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(previousValue) > 0 {
+		v, err := strconv.ParseInt(string(previousValue), 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to decode previous TTL monitor interval", err.Error())
+			return
+		}
+		previous = v
+	}
+
+	if err := r.setSleep(ctx, previous); err != nil {
+		resp.Diagnostics.AddError("setParameter failed", err.Error())
+	}
+}
+
+func (r *TTLMonitorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *TTLMonitorResource) getSleep(ctx context.Context) (int64, error) {
+	var result bson.Raw
+	cmd := bson.D{{Key: "getParameter", Value: 1}, {Key: ttlMonitorSleepParameter, Value: 1}}
+	if err := r.client.Database("admin").RunCommand(ctx, cmd).Decode(&result); err != nil {
+		return 0, err
+	}
+
+	v, ok := result.Lookup(ttlMonitorSleepParameter).AsInt64OK()
+	if !ok {
+		return 0, fmt.Errorf("server did not report %s", ttlMonitorSleepParameter)
+	}
+	return v, nil
+}
+
+func (r *TTLMonitorResource) setSleep(ctx context.Context, seconds int64) error {
+	cmd := bson.D{{Key: "setParameter", Value: 1}, {Key: ttlMonitorSleepParameter, Value: seconds}}
+	return r.client.Database("admin").RunCommand(ctx, cmd).Err()
+}
@@ -0,0 +1,75 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+const ttlMonitorType = "mongodb_ttl_monitor"
+
+// setSleeps returns the ttlMonitorSleepSecs values sent with setParameter, in order.
+func setSleeps(mt *mtest.T) []int64 {
+	var values []int64
+	for _, cmd := range startedCommands(mt, "setParameter") {
+		values = append(values, cmd.Lookup(ttlMonitorSleepParameter).AsInt64())
+	}
+	return values
+}
+
+func TestTTLMonitorRestoresPrevious(t *testing.T) {
+	r := NewTTLMonitorResource()
+	mt := newMockTest(t)
+
+	mt.Run("previous interval", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: ttlMonitorSleepParameter, Value: int32(120)}),
+			mtest.CreateSuccessResponse(),
+		)
+		plan := &TTLMonitorResourceModel{ID: types.StringUnknown(), SleepSeconds: types.Int64Value(30)}
+		created := applyChange(mt.T, mt, ttlMonitorType, r, nil, plan, nil)
+
+		get := startedCommands(mt, "getParameter")
+		if len(get) != 1 || get[0].Lookup(ttlMonitorSleepParameter).AsInt64() != 1 {
+			mt.Fatalf("getParameter = %v, want ttlMonitorSleepSecs", get)
+		}
+		if got := setSleeps(mt); len(got) != 1 || got[0] != 30 {
+			mt.Fatalf("set %v, want 30", got)
+		}
+
+		var state TTLMonitorResourceModel
+		newStateOf(mt.T, r, created, &state)
+		mt.ClearEvents()
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		applyChange(mt.T, mt, ttlMonitorType, r, &state, nil, created.Private)
+		if got := setSleeps(mt); len(got) != 1 || got[0] != 120 {
+			mt.Errorf("restored %v, want 120", got)
+		}
+	})
+
+	mt.Run("delete after import", func(mt *mtest.T) {
+		state := &TTLMonitorResourceModel{ID: types.StringValue(ttlMonitorSleepParameter), SleepSeconds: types.Int64Value(30)}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		applyChange(mt.T, mt, ttlMonitorType, r, state, nil, nil)
+		if got := setSleeps(mt); len(got) != 1 || got[0] != defaultTTLMonitorSleepSecs {
+			mt.Errorf("restored %v, want the default %d", got, defaultTTLMonitorSleepSecs)
+		}
+	})
+}
+
+func TestTTLMonitorRead(t *testing.T) {
+	mt := newMockTest(t)
+
+	mt.Run("interval", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: ttlMonitorSleepParameter, Value: int32(45)}))
+		prior := TTLMonitorResourceModel{ID: types.StringValue(ttlMonitorSleepParameter), SleepSeconds: types.Int64Value(30)}
+
+		var state TTLMonitorResourceModel
+		readResource(mt.T, mt, &TTLMonitorResource{}, &prior, &state)
+		if got := state.SleepSeconds.ValueInt64(); got != 45 {
+			mt.Errorf("sleep_seconds = %d, want 45", got)
+		}
+	})
+}
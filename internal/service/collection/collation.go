@@ -0,0 +1,229 @@
+package collection
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CollationModel struct {
+	Locale          types.String `tfsdk:"locale"`
+	Strength        types.Int64  `tfsdk:"strength"`
+	CaseLevel       types.Bool   `tfsdk:"case_level"`
+	CaseFirst       types.String `tfsdk:"case_first"`
+	NumericOrdering types.Bool   `tfsdk:"numeric_ordering"`
+	Alternate       types.String `tfsdk:"alternate"`
+	MaxVariable     types.String `tfsdk:"max_variable"`
+	Backwards       types.Bool   `tfsdk:"backwards"`
+	Normalization   types.Bool   `tfsdk:"normalization"`
+}
+
+func collationBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Default collation of the collection. Collation can't be changed, so any change recreates the collection.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"locale": schema.StringAttribute{
+				Optional:    true,
+				Description: "ICU locale, e.g. 'en' or 'fr_CA'. Required when the block is set.",
+			},
+			"strength": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Comparison level, 1 through 5; 2 compares case-insensitively.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 5),
+				},
+			},
+			"case_level": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, includes case comparison at strength 1 or 2.",
+			},
+			"case_first": schema.StringAttribute{
+				Optional:    true,
+				Description: "Sort order of case differences. One of 'upper', 'lower', or 'off'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("upper", "lower", "off"),
+				},
+			},
+			"numeric_ordering": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, compares numeric strings as numbers.",
+			},
+			"alternate": schema.StringAttribute{
+				Optional:    true,
+				Description: "Whether whitespace and punctuation are base characters. One of 'non-ignorable' or 'shifted'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("non-ignorable", "shifted"),
+				},
+			},
+			"max_variable": schema.StringAttribute{
+				Optional:    true,
+				Description: "Characters ignored when alternate is 'shifted'. One of 'punct' or 'space'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("punct", "space"),
+				},
+			},
+			"backwards": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, strings with diacritics sort from the back of the string.",
+			},
+			"normalization": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, checks whether text requires normalization and performs it.",
+			},
+		},
+	}
+}
+
+func (c *CollationModel) toOptions() *options.Collation {
+	return &options.Collation{
+		Locale:          c.Locale.ValueString(),
+		Strength:        int(c.Strength.ValueInt64()),
+		CaseLevel:       c.CaseLevel.ValueBool(),
+		CaseFirst:       c.CaseFirst.ValueString(),
+		NumericOrdering: c.NumericOrdering.ValueBool(),
+		Alternate:       c.Alternate.ValueString(),
+		MaxVariable:     c.MaxVariable.ValueString(),
+		Backwards:       c.Backwards.ValueBool(),
+		Normalization:   c.Normalization.ValueBool(),
+	}
+}
+
+// decodeCollation reads a collation document as reported by the server, which fills in every field.
+func decodeCollation(doc bson.Raw) *CollationModel {
+	str := func(key string) types.String {
+		if v, ok := doc.Lookup(key).StringValueOK(); ok {
+			return types.StringValue(v)
+		}
+		return types.StringNull()
+	}
+	boolean := func(key string) types.Bool {
+		if v, ok := doc.Lookup(key).BooleanOK(); ok {
+			return types.BoolValue(v)
+		}
+		return types.BoolNull()
+	}
+
+	c := &CollationModel{
+		Locale:          str("locale"),
+		Strength:        types.Int64Null(),
+		CaseLevel:       boolean("caseLevel"),
+		CaseFirst:       str("caseFirst"),
+		NumericOrdering: boolean("numericOrdering"),
+		Alternate:       str("alternate"),
+		MaxVariable:     str("maxVariable"),
+		Backwards:       boolean("backwards"),
+		Normalization:   boolean("normalization"),
+	}
+	if v, ok := doc.Lookup("strength").AsInt64OK(); ok {
+		c.Strength = types.Int64Value(v)
+	}
+	return c
+}
+
+// collationDefaults returns the values ICU fills in for a locale's collation fields that aren't given.
+// A few locales tailor the root defaults.
+func collationDefaults(locale string) CollationModel {
+	defaults := CollationModel{
+		Strength:        types.Int64Value(3),
+		CaseLevel:       types.BoolValue(false),
+		CaseFirst:       types.StringValue("off"),
+		NumericOrdering: types.BoolValue(false),
+		Alternate:       types.StringValue("non-ignorable"),
+		MaxVariable:     types.StringValue("punct"),
+		Backwards:       types.BoolValue(false),
+		Normalization:   types.BoolValue(false),
+	}
+	switch strings.SplitN(locale, "@", 2)[0] {
+	case "fr_CA":
+		defaults.Backwards = types.BoolValue(true)
+	case "da", "mt":
+		defaults.CaseFirst = types.StringValue("upper")
+	case "th":
+		defaults.Alternate = types.StringValue("shifted")
+	}
+	return defaults
+}
+
+// importedCollation is the prior collation to reconcile against when there is no prior state: only the fields
+// that differ from the locale's defaults count as configured.
+func importedCollation(current *CollationModel) *CollationModel {
+	defaults := collationDefaults(current.Locale.ValueString())
+	prior := *current
+	if current.Strength.Equal(defaults.Strength) {
+		prior.Strength = types.Int64Null()
+	}
+	if current.CaseLevel.Equal(defaults.CaseLevel) {
+		prior.CaseLevel = types.BoolNull()
+	}
+	if current.CaseFirst.Equal(defaults.CaseFirst) {
+		prior.CaseFirst = types.StringNull()
+	}
+	if current.NumericOrdering.Equal(defaults.NumericOrdering) {
+		prior.NumericOrdering = types.BoolNull()
+	}
+	if current.Alternate.Equal(defaults.Alternate) {
+		prior.Alternate = types.StringNull()
+	}
+	if current.MaxVariable.Equal(defaults.MaxVariable) {
+		prior.MaxVariable = types.StringNull()
+	}
+	if current.Backwards.Equal(defaults.Backwards) {
+		prior.Backwards = types.BoolNull()
+	}
+	if current.Normalization.Equal(defaults.Normalization) {
+		prior.Normalization = types.BoolNull()
+	}
+	return &prior
+}
+
+// reconcileCollation drops the server-filled defaults for fields that weren't configured and returns the
+// collation to store in state.
+func reconcileCollation(prior, current *CollationModel) *CollationModel {
+	// The "simple" locale is binary comparison, which the server reports as no collation at all
+	if current == nil && prior != nil && prior.Locale.ValueString() == "simple" {
+		return prior
+	}
+	if current == nil {
+		return nil
+	}
+	if prior == nil {
+		prior = importedCollation(current)
+	}
+
+	if prior.Strength.IsNull() {
+		current.Strength = types.Int64Null()
+	}
+	if prior.CaseLevel.IsNull() {
+		current.CaseLevel = types.BoolNull()
+	}
+	if prior.CaseFirst.IsNull() {
+		current.CaseFirst = types.StringNull()
+	}
+	if prior.NumericOrdering.IsNull() {
+		current.NumericOrdering = types.BoolNull()
+	}
+	if prior.Alternate.IsNull() {
+		current.Alternate = types.StringNull()
+	}
+	if prior.MaxVariable.IsNull() {
+		current.MaxVariable = types.StringNull()
+	}
+	if prior.Backwards.IsNull() {
+		current.Backwards = types.BoolNull()
+	}
+	if prior.Normalization.IsNull() {
+		current.Normalization = types.BoolNull()
+	}
+	return current
+}
@@ -0,0 +1,78 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// serverCollation is a collation as the server reports it, with every field filled in.
+func serverCollation(locale string, strength int64, backwards bool) *CollationModel {
+	return &CollationModel{
+		Locale:          types.StringValue(locale),
+		Strength:        types.Int64Value(strength),
+		CaseLevel:       types.BoolValue(false),
+		CaseFirst:       types.StringValue("off"),
+		NumericOrdering: types.BoolValue(false),
+		Alternate:       types.StringValue("non-ignorable"),
+		MaxVariable:     types.StringValue("punct"),
+		Backwards:       types.BoolValue(backwards),
+		Normalization:   types.BoolValue(false),
+	}
+}
+
+func TestReconcileCollation(t *testing.T) {
+	cases := map[string]struct {
+		prior   *CollationModel
+		current *CollationModel
+		want    *CollationModel
+	}{
+		"import keeps only non-default fields": {
+			current: serverCollation("en", 2, false),
+			want: &CollationModel{
+				Locale:   types.StringValue("en"),
+				Strength: types.Int64Value(2),
+			},
+		},
+		"import uses the locale's own defaults": {
+			current: serverCollation("fr_CA", 3, true),
+			want: &CollationModel{
+				Locale: types.StringValue("fr_CA"),
+			},
+		},
+		"import of a default-valued field that differs for the locale": {
+			current: serverCollation("fr_CA", 3, false),
+			want: &CollationModel{
+				Locale:    types.StringValue("fr_CA"),
+				Backwards: types.BoolValue(false),
+			},
+		},
+		"configured defaults are kept": {
+			prior: &CollationModel{
+				Locale:    types.StringValue("en"),
+				Strength:  types.Int64Value(3),
+				CaseLevel: types.BoolValue(false),
+			},
+			current: serverCollation("en", 3, false),
+			want: &CollationModel{
+				Locale:    types.StringValue("en"),
+				Strength:  types.Int64Value(3),
+				CaseLevel: types.BoolValue(false),
+			},
+		},
+		"simple locale is kept from state": {
+			prior: &CollationModel{Locale: types.StringValue("simple")},
+			want:  &CollationModel{Locale: types.StringValue("simple")},
+		},
+		"no collation": {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := reconcileCollation(tc.prior, tc.current)
+			if (got == nil) != (tc.want == nil) || (got != nil && *got != *tc.want) {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
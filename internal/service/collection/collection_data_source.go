@@ -3,14 +3,20 @@ package collection
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// existencePollInterval is how often the data source re-checks for the collection while waiting.
+const existencePollInterval = 2 * time.Second
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &DataSource{}
 var _ datasource.DataSourceWithConfigure = &DataSource{}
@@ -24,9 +30,24 @@ type DataSource struct {
 }
 
 type DataSourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Database types.String `tfsdk:"database"`
-	Name     types.String `tfsdk:"name"`
+	ID        types.String `tfsdk:"id"`
+	Database  types.String `tfsdk:"database"`
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+
+	WaitForExistenceSeconds types.Int64 `tfsdk:"wait_for_existence_seconds"`
+
+	UUID     types.String `tfsdk:"uuid"`
+	IDIndex  types.String `tfsdk:"id_index"`
+	ReadOnly types.Bool   `tfsdk:"read_only"`
+
+	Validator        ValidatorValue `tfsdk:"validator"`
+	ValidatorKind    types.String   `tfsdk:"validator_type"`
+	ValidationLevel  types.String   `tfsdk:"validation_level"`
+	ValidationAction types.String   `tfsdk:"validation_action"`
+
+	WriteConcern types.String `tfsdk:"write_concern"`
+	ReadConcern  types.String `tfsdk:"read_concern"`
 
 	TimeSeries *TimeSeriesModel `tfsdk:"timeseries"`
 }
@@ -50,6 +71,54 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Required:    true,
 				Description: "Collection name.",
 			},
+			"namespace": schema.StringAttribute{
+				Computed:    true,
+				Description: "Dotted namespace of the collection, i.e. 'database.collection'.",
+			},
+			"wait_for_existence_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Description: "If set, keeps polling for up to this many seconds until the collection exists instead of failing immediately.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"uuid": schema.StringAttribute{
+				Computed:    true,
+				Description: "Collection UUID.",
+			},
+			"id_index": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the collection's _id index.",
+			},
+			"read_only": schema.BoolAttribute{
+				Computed:    true,
+				Description: "True if the collection is read-only.",
+			},
+			"validator": schema.StringAttribute{
+				CustomType:  ValidatorType{},
+				Computed:    true,
+				Description: "JSON string of the validator expression",
+			},
+			"validator_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "'jsonSchema' if validator is a $jsonSchema body, 'query' for a query-operator validator; null without a validator.",
+			},
+			"validation_level": schema.StringAttribute{
+				Computed:    true,
+				Description: "Validation level",
+			},
+			"validation_action": schema.StringAttribute{
+				Computed:    true,
+				Description: "Validation action",
+			},
+			"write_concern": schema.StringAttribute{
+				Computed:    true,
+				Description: "Collection-level write concern as JSON, if the server reports one; null when the cluster default applies.",
+			},
+			"read_concern": schema.StringAttribute{
+				Computed:    true,
+				Description: "Collection-level read concern as JSON, if the server reports one; null when the cluster default applies.",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"timeseries": schema.SingleNestedBlock{
@@ -112,7 +181,19 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 	}
 
 	db := d.client.Database(plan.Database.ValueString())
-	collections, err := db.ListCollectionSpecifications(ctx, bson.D{{Key: "name", Value: plan.Name.ValueString()}})
+	filter := bson.D{{Key: "name", Value: plan.Name.ValueString()}}
+	collections, err := db.ListCollectionSpecifications(ctx, filter)
+
+	deadline := time.Now().Add(time.Duration(plan.WaitForExistenceSeconds.ValueInt64()) * time.Second)
+	for err == nil && len(collections) == 0 && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError("Error reading collection", ctx.Err().Error())
+			return
+		case <-time.After(existencePollInterval):
+		}
+		collections, err = db.ListCollectionSpecifications(ctx, filter)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading collection",
@@ -127,50 +208,30 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
-	collection := collections[0]
-	if collection.Options != nil {
-		if tsVal := collection.Options.Lookup("timeseries"); tsVal.Type == bson.TypeEmbeddedDocument {
-			tsDoc := tsVal.Document()
-			var tsState TimeSeriesModel
-
-			if f := tsDoc.Lookup("timeField"); f.Type == bson.TypeString {
-				tsState.TimeField = types.StringValue(f.StringValue())
-			}
-			if f := tsDoc.Lookup("metaField"); f.Type == bson.TypeString {
-				tsState.MetaField = types.StringValue(f.StringValue())
-			} else {
-				tsState.MetaField = types.StringNull()
-			}
-			if f := tsDoc.Lookup("granularity"); f.Type == bson.TypeString {
-				tsState.Granularity = types.StringValue(f.StringValue())
-			} else {
-				tsState.Granularity = types.StringNull()
-			}
-			if value, ok := tsDoc.Lookup("bucketMaxSpanSeconds").AsInt64OK(); ok {
-				tsState.BucketMaxSpanSeconds = types.Int64Value(value)
-			} else {
-				tsState.BucketMaxSpanSeconds = types.Int64Null()
-			}
-			if value, ok := tsDoc.Lookup("bucketRoundingSeconds").AsInt64OK(); ok {
-				tsState.BucketRoundingSeconds = types.Int64Value(value)
-			} else {
-				tsState.BucketRoundingSeconds = types.Int64Null()
-			}
-
-			if value, ok := collection.Options.Lookup("expireAfterSeconds").AsInt64OK(); ok {
-				tsState.ExpireAfterSeconds = types.Int64Value(value)
-			} else {
-				tsState.ExpireAfterSeconds = types.Int64Null()
-			}
-
-			plan.TimeSeries = &tsState
-		} else {
-			plan.TimeSeries = nil
-		}
-	} else {
-		plan.TimeSeries = nil
+	info, err := decodeSpecification(collections[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to decode collection specification", err.Error())
+		return
+	}
+
+	plan.Validator = info.Validator
+	plan.ValidatorKind = info.ValidatorKind
+	plan.ValidationLevel = info.ValidationLevel
+	if plan.ValidationLevel.IsNull() {
+		plan.ValidationLevel = types.StringValue(defaultValidationLevel)
+	}
+	plan.ValidationAction = info.ValidationAction
+	if plan.ValidationAction.IsNull() {
+		plan.ValidationAction = types.StringValue(defaultValidationAction)
 	}
+	plan.TimeSeries = info.TimeSeries
+	plan.UUID = info.UUID
+	plan.IDIndex = info.IDIndex
+	plan.ReadOnly = info.ReadOnly
+	plan.WriteConcern = info.WriteConcern
+	plan.ReadConcern = info.ReadConcern
 
 	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Name.ValueString()))
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Name.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
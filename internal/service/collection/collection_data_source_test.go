@@ -0,0 +1,68 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// TestDataSourceReadParity checks that the data source reports the same settings as an imported resource for
+// the same collection, since both decode the listCollections entry with decodeSpecification.
+func TestDataSourceReadParity(t *testing.T) {
+	cases := map[string]bson.D{
+		"json schema validator": {
+			{Key: "validator", Value: bson.D{{Key: "$jsonSchema", Value: bson.D{
+				{Key: "bsonType", Value: "object"},
+				{Key: "required", Value: bson.A{"name"}},
+			}}}},
+			{Key: "validationLevel", Value: "moderate"},
+			{Key: "validationAction", Value: "warn"},
+		},
+		"query validator": {
+			{Key: "validator", Value: bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: int32(0)}}}}},
+		},
+		"no validator": {},
+		"time series": {
+			{Key: "timeseries", Value: bson.D{
+				{Key: "timeField", Value: "ts"},
+				{Key: "metaField", Value: "meta"},
+				{Key: "bucketMaxSpanSeconds", Value: int32(3600)},
+				{Key: "bucketRoundingSeconds", Value: int32(3600)},
+			}},
+			{Key: "expireAfterSeconds", Value: int64(600)},
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, options := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(listCollectionsResponse(collectionSpec("things", options)))
+			state := importResource(mt.T, mt.Client, "db/things")
+
+			mt.AddMockResponses(listCollectionsResponse(collectionSpec("things", options)))
+			data := readDataSource(mt.T, mt.Client, DataSourceModel{
+				Database: types.StringValue("db"),
+				Name:     types.StringValue("things"),
+			})
+
+			if !state.Validator.Equal(data.Validator) {
+				mt.Errorf("validator: resource %s, data source %s", state.Validator, data.Validator)
+			}
+			// Without a validator the resource keeps its validator_type default and the data source reports null
+			if !data.Validator.IsNull() && !state.ValidatorKind.Equal(data.ValidatorKind) {
+				mt.Errorf("validator_type: resource %s, data source %s", state.ValidatorKind, data.ValidatorKind)
+			}
+			if !state.ValidationLevel.Equal(data.ValidationLevel) {
+				mt.Errorf("validation_level: resource %s, data source %s", state.ValidationLevel, data.ValidationLevel)
+			}
+			if !state.ValidationAction.Equal(data.ValidationAction) {
+				mt.Errorf("validation_action: resource %s, data source %s", state.ValidationAction, data.ValidationAction)
+			}
+			if (state.TimeSeries == nil) != (data.TimeSeries == nil) || state.TimeSeries != nil && *state.TimeSeries != *data.TimeSeries {
+				mt.Errorf("timeseries: resource %+v, data source %+v", state.TimeSeries, data.TimeSeries)
+			}
+		})
+	}
+}
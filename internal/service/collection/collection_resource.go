@@ -6,12 +6,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/datafy-io/terraform-provider-mongodb/internal/importid"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/retry"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -23,6 +31,15 @@ import (
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithConfigure = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
+
+// resourceTimeouts are the operation time limits used without a timeouts block.
+var resourceTimeouts = timeouts.Defaults{
+	Create: 10 * time.Minute,
+	Update: 10 * time.Minute,
+	Delete: 10 * time.Minute,
+}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -45,9 +62,31 @@ type ResourceModel struct {
 	ID             types.String `tfsdk:"id"`
 	Database       types.String `tfsdk:"database"`
 	Name           types.String `tfsdk:"name"`
+	Namespace      types.String `tfsdk:"namespace"`
 	PreventDestroy types.Bool   `tfsdk:"prevent_destroy"`
+	DropTarget     types.Bool   `tfsdk:"drop_target"`
+
+	AdoptExisting    types.Bool `tfsdk:"adopt_existing"`
+	AdoptOnlyIfEmpty types.Bool `tfsdk:"adopt_only_if_empty"`
+
+	Validator        ValidatorValue `tfsdk:"validator"`
+	ValidatorKind    types.String   `tfsdk:"validator_type"`
+	ValidationLevel  types.String   `tfsdk:"validation_level"`
+	ValidationAction types.String   `tfsdk:"validation_action"`
+
+	NoPadding types.Bool `tfsdk:"no_padding"`
+
+	Capped       types.Bool  `tfsdk:"capped"`
+	SizeBytes    types.Int64 `tfsdk:"size_bytes"`
+	MaxDocuments types.Int64 `tfsdk:"max_documents"`
+
+	ChangeStreamPreAndPostImages types.Bool `tfsdk:"change_stream_pre_and_post_images"`
+
+	Collation *CollationModel `tfsdk:"collation"`
 
 	TimeSeries *TimeSeriesModel `tfsdk:"timeseries"`
+
+	Timeouts *timeouts.Model `tfsdk:"timeouts"`
 }
 
 func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -91,9 +130,19 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
-				Description: "Collection name.",
+				Description: "Collection name. Changing it renames the collection in place with renameCollection, keeping its data.",
+			},
+			"drop_target": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, renaming the collection drops an existing collection with the new name instead of failing (renameCollection dropTarget). (Default: false)",
+			},
+			"namespace": schema.StringAttribute{
+				Computed:    true,
+				Description: "Dotted namespace of the collection, i.e. 'database.collection'.",
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"prevent_destroy": schema.BoolAttribute{
@@ -102,8 +151,94 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Default:     booldefault.StaticBool(false),
 				Description: "If true, prevents the collection from being destroyed. (Default: false)",
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, an existing collection with the same name is brought under management instead of failing creation. Its options are reconciled on the next plan. (Default: false)",
+			},
+			"adopt_only_if_empty": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "If true, adopt_existing only adopts a collection that holds no documents. Requires adopt_existing. (Default: false)",
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("adopt_existing")),
+				},
+			},
+			"validator": schema.StringAttribute{
+				CustomType:  ValidatorType{},
+				Optional:    true,
+				Description: "JSON string for validator. With validator_type 'jsonSchema' this is the schema without the $jsonSchema prefix; with 'query' it is the full query-expression document, e.g. {\"$expr\": ...}.",
+			},
+			"validator_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(validatorKindJSONSchema),
+				Description: "How validator is interpreted: 'jsonSchema' wraps it in $jsonSchema, 'query' uses it as a query-operator validator. (Default: jsonSchema)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(validatorKindJSONSchema, validatorKindQuery),
+				},
+			},
+			"validation_level": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultValidationLevel),
+				Description: "Validation level for the collection. Can be 'off', 'strict', or 'moderate'. (Default: strict)",
+				Validators: []validator.String{
+					stringvalidator.OneOf("off", "strict", "moderate"),
+				},
+			},
+			"validation_action": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultValidationAction),
+				Description: "Action to take when validation fails. Can be 'error', 'warn', or 'errorAndLog' (MongoDB 8.1+). (Default: error)",
+				Validators: []validator.String{
+					stringvalidator.OneOf("error", "warn", "errorAndLog"),
+				},
+			},
+			"no_padding": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, disables record padding (noPadding). Only supported by the MMAPv1 storage engine; ignored with a warning otherwise.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"capped": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, creates a fixed-size capped collection. Requires size_bytes.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"size_bytes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum size in bytes of a capped collection. MongoDB rounds it up to a multiple of 256.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"max_documents": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of documents in a capped collection.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"change_stream_pre_and_post_images": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, change streams can return the document before and after each change (changeStreamPreAndPostImages). Can be changed in place.",
+			},
 		},
 		Blocks: map[string]schema.Block{
+			"collation": collationBlock(),
 			"timeseries": schema.SingleNestedBlock{
 				Description: "MongoDB time-series collection options. If set, the collection will be created as a time-series collection.",
 				Attributes: map[string]schema.Attribute{
@@ -130,21 +265,35 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 							stringvalidator.OneOf("seconds", "minutes", "hours"),
 						},
 						PlanModifiers: []planmodifier.String{
-							stringplanmodifier.RequiresReplace(),
+							stringplanmodifier.RequiresReplaceIf(
+								granularityRequiresReplace,
+								"Granularity can only be made coarser in place.",
+								"Granularity can only be made coarser in place.",
+							),
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
 					"bucket_max_span_seconds": schema.Int64Attribute{
 						Optional:    true,
-						Description: "Maximum span (in seconds) for each bucket.",
+						Description: "Maximum span (in seconds) for each bucket. Can only be increased in place.",
 						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplaceIf(
+								bucketRequiresReplace,
+								"Custom bucketing can only be increased in place.",
+								"Custom bucketing can only be increased in place.",
+							),
 							int64planmodifier.UseStateForUnknown(),
 						},
 					},
 					"bucket_rounding_seconds": schema.Int64Attribute{
 						Optional:    true,
-						Description: "Rounding (in seconds) used to align bucket boundaries.",
+						Description: "Rounding (in seconds) used to align bucket boundaries. Can only be increased in place.",
 						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.RequiresReplaceIf(
+								bucketRequiresReplace,
+								"Custom bucketing can only be increased in place.",
+								"Custom bucketing can only be increased in place.",
+							),
 							int64planmodifier.UseStateForUnknown(),
 						},
 					},
@@ -157,10 +306,69 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 					},
 				},
 			},
+			"timeouts": timeouts.Block(resourceTimeouts),
 		},
 	}
 }
 
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Capped.ValueBool() && config.SizeBytes.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("size_bytes"),
+			"Missing size_bytes",
+			"size_bytes is required when capped is true.",
+		)
+	}
+	if !config.Capped.ValueBool() && !config.Capped.IsUnknown() && (!config.SizeBytes.IsNull() || !config.MaxDocuments.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("capped"),
+			"Capped options without capped",
+			"size_bytes and max_documents only apply to capped collections; set capped = true.",
+		)
+	}
+
+	if config.Collation != nil && config.Collation.Locale.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("collation").AtName("locale"),
+			"Missing collation locale",
+			"locale is required when a collation block is set.",
+		)
+	}
+
+	if config.TimeSeries != nil && config.TimeSeries.MetaField.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("timeseries").AtName("meta_field"),
+			"Time-series collection without meta_field",
+			"meta_field can't be added after the collection is created, and time-series updates and deletes can only filter on the meta field. "+
+				"Set meta_field unless the collection is strictly append-only.",
+		)
+	}
+}
+
+// ModifyPlan marks id and namespace as changing when the collection is renamed in place.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planName, stateName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+	if resp.Diagnostics.HasError() || planName.Equal(stateName) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("namespace"), types.StringUnknown())...)
+}
+
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -168,8 +376,56 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	ctx, cancel := plan.Timeouts.CreateContext(ctx, resourceTimeouts)
+	defer cancel()
+
+	if plan.AdoptExisting.ValueBool() {
+		adopted, err := r.adopt(ctx, plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to adopt existing collection", err.Error())
+			return
+		}
+		if adopted {
+			plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Name.ValueString()))
+			plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Name.ValueString()))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			return
+		}
+	}
+
 	opts := &options.CreateCollectionOptions{}
 
+	if v := plan.Validator.ValueString(); v != "" {
+		validatorDoc, err := buildValidator(v, plan.ValidatorKind.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("invalid validator JSON", err.Error())
+			return
+		}
+		opts = opts.SetValidator(validatorDoc)
+	}
+	// The defaults are left to the server, which applies the same ones
+	if v := plan.ValidationLevel.ValueString(); v != "" && v != defaultValidationLevel {
+		opts = opts.SetValidationLevel(v)
+	}
+	if v := plan.ValidationAction.ValueString(); v != "" && v != defaultValidationAction {
+		opts = opts.SetValidationAction(v)
+	}
+
+	if plan.ChangeStreamPreAndPostImages.ValueBool() {
+		opts = opts.SetChangeStreamPreAndPostImages(bson.D{{Key: "enabled", Value: true}})
+	}
+
+	if plan.Collation != nil {
+		opts = opts.SetCollation(plan.Collation.toOptions())
+	}
+
+	if plan.Capped.ValueBool() {
+		opts = opts.SetCapped(true).SetSizeInBytes(plan.SizeBytes.ValueInt64())
+		if !plan.MaxDocuments.IsNull() {
+			opts = opts.SetMaxDocuments(plan.MaxDocuments.ValueInt64())
+		}
+	}
+
 	if plan.TimeSeries != nil {
 		ts := options.TimeSeries()
 		ts.SetTimeField(plan.TimeSeries.TimeField.ValueString())
@@ -193,15 +449,73 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		opts = opts.SetTimeSeriesOptions(ts)
 	}
 
-	if err := r.client.Database(plan.Database.ValueString()).CreateCollection(ctx, plan.Name.ValueString(), opts); err != nil {
+	// The engine is checked before creating so a failure here leaves nothing behind
+	var engine string
+	if plan.NoPadding.ValueBool() {
+		var err error
+		engine, err = storageEngine(ctx, r.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to determine storage engine", err.Error())
+			return
+		}
+	}
+
+	db := r.client.Database(plan.Database.ValueString())
+	err := retry.DoApplied(ctx, r.client, func(ctx context.Context) error {
+		return db.CreateCollection(ctx, plan.Name.ValueString(), opts)
+	}, namespaceExistsCode)
+	if err != nil {
 		resp.Diagnostics.AddError("create collection failed", err.Error())
 		return
 	}
 
+	if plan.NoPadding.ValueBool() {
+		if engine == mmapv1Engine {
+			cmd := bson.D{{Key: "collMod", Value: plan.Name.ValueString()}, {Key: "noPadding", Value: true}}
+			if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+				// The collection is not in state yet, so drop it rather than leave it behind
+				if dropErr := db.Collection(plan.Name.ValueString()).Drop(ctx); dropErr != nil {
+					err = fmt.Errorf("%w; dropping the new collection also failed: %s", err, dropErr)
+				}
+				resp.Diagnostics.AddError("set noPadding failed", err.Error())
+				return
+			}
+		} else {
+			resp.Diagnostics.AddWarning(
+				"no_padding is not supported",
+				fmt.Sprintf("The %q storage engine does not support noPadding; the setting has no effect.", engine),
+			)
+		}
+	}
+
 	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Name.ValueString()))
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Name.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// adopt reports whether the planned collection already exists and may be taken over.
+func (r *Resource) adopt(ctx context.Context, plan ResourceModel) (bool, error) {
+	db := r.client.Database(plan.Database.ValueString())
+	names, err := db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: plan.Name.ValueString()}})
+	if err != nil {
+		return false, err
+	}
+	if len(names) == 0 {
+		return false, nil
+	}
+
+	if plan.AdoptOnlyIfEmpty.ValueBool() {
+		count, err := db.Collection(plan.Name.ValueString()).CountDocuments(ctx, bson.D{}, options.Count().SetLimit(1))
+		if err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return false, fmt.Errorf("collection %s.%s already holds documents and adopt_only_if_empty is set", plan.Database.ValueString(), plan.Name.ValueString())
+		}
+	}
+	return true, nil
+}
+
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state ResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -218,58 +532,63 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		)
 		return
 	}
-	if collections == nil || len(collections) != 1 {
+	if len(collections) == 0 {
+		// Collection is genuinely gone (e.g. dropped out of band or a stale import ID)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if len(collections) != 1 {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Collection not found db: %s col name: %s", state.Database.ValueString(), state.Name.ValueString()), fmt.Sprintf("%d", len(collections)),
 		)
 		return
 	}
 
-	collection := collections[0]
-	if collection.Options != nil {
-		if tsVal := collection.Options.Lookup("timeseries"); tsVal.Type == bson.TypeEmbeddedDocument {
-			tsDoc := tsVal.Document()
-			var tsState TimeSeriesModel
-
-			if f := tsDoc.Lookup("timeField"); f.Type == bson.TypeString {
-				tsState.TimeField = types.StringValue(f.StringValue())
-			}
-			if f := tsDoc.Lookup("metaField"); f.Type == bson.TypeString {
-				tsState.MetaField = types.StringValue(f.StringValue())
-			} else {
-				tsState.MetaField = types.StringNull()
-			}
-			if f := tsDoc.Lookup("granularity"); f.Type == bson.TypeString {
-				tsState.Granularity = types.StringValue(f.StringValue())
-			} else {
-				tsState.Granularity = types.StringNull()
-			}
-			if value, ok := tsDoc.Lookup("bucketMaxSpanSeconds").AsInt64OK(); ok {
-				tsState.BucketMaxSpanSeconds = types.Int64Value(value)
-			} else {
-				tsState.BucketMaxSpanSeconds = types.Int64Null()
-			}
-			if value, ok := tsDoc.Lookup("bucketRoundingSeconds").AsInt64OK(); ok {
-				tsState.BucketRoundingSeconds = types.Int64Value(value)
-			} else {
-				tsState.BucketRoundingSeconds = types.Int64Null()
-			}
-
-			if value, ok := collection.Options.Lookup("expireAfterSeconds").AsInt64OK(); ok {
-				tsState.ExpireAfterSeconds = types.Int64Value(value)
-			} else {
-				tsState.ExpireAfterSeconds = types.Int64Null()
-			}
+	info, err := decodeSpecification(collections[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to decode collection specification", err.Error())
+		return
+	}
 
-			state.TimeSeries = &tsState
-		} else {
-			state.TimeSeries = nil
-		}
-	} else {
-		state.TimeSeries = nil
+	state.Validator = info.Validator
+	// Without a validator there's nothing to tell the kind from; keep the configured one. A query validator that is
+	// only a $jsonSchema reads back like the jsonSchema kind, so it keeps its configured kind too.
+	if state.ValidatorKind.ValueString() == validatorKindQuery && info.ValidatorKind.ValueString() == validatorKindJSONSchema {
+		state.Validator = asQueryValidator(info.Validator)
+	} else if !info.ValidatorKind.IsNull() {
+		state.ValidatorKind = info.ValidatorKind
+	}
+	// The server omits validationLevel/validationAction when they are the defaults
+	state.ValidationLevel = info.ValidationLevel
+	if state.ValidationLevel.IsNull() {
+		state.ValidationLevel = types.StringValue(defaultValidationLevel)
+	}
+	state.ValidationAction = info.ValidationAction
+	if state.ValidationAction.IsNull() {
+		state.ValidationAction = types.StringValue(defaultValidationAction)
+	}
+	// flags is only reported by MMAPv1; leave the configured value alone otherwise
+	if !info.NoPadding.IsNull() {
+		state.NoPadding = info.NoPadding
+	}
+	// Only read capped when it was configured or is set, like the other optional flags
+	if info.Capped.ValueBool() || !state.Capped.IsNull() {
+		state.Capped = info.Capped
 	}
+	// The server rounds the size up to a multiple of 256 bytes; keep the configured value when it maps to the same size
+	if state.SizeBytes.IsNull() || info.SizeBytes.IsNull() || cappedSize(state.SizeBytes.ValueInt64()) != info.SizeBytes.ValueInt64() {
+		state.SizeBytes = info.SizeBytes
+	}
+	state.MaxDocuments = info.MaxDocuments
+	if info.ChangeStreamPreAndPostImages.ValueBool() || !state.ChangeStreamPreAndPostImages.IsNull() {
+		state.ChangeStreamPreAndPostImages = info.ChangeStreamPreAndPostImages
+	}
+	state.Collation = reconcileCollation(state.Collation, info.Collation)
+	reconcileTimeSeries(state.TimeSeries, info.TimeSeries)
+	state.TimeSeries = info.TimeSeries
 
 	state.ID = types.StringValue(fmt.Sprintf("%s/%s", state.Database.ValueString(), state.Name.ValueString()))
+	state.Namespace = types.StringValue(fmt.Sprintf("%s.%s", state.Database.ValueString(), state.Name.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -282,21 +601,68 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	// Only validator-related updates via collMod
+	ctx, cancel := plan.Timeouts.UpdateContext(ctx, resourceTimeouts)
+	defer cancel()
+
+	if !plan.Name.Equal(state.Name) {
+		cmd := bson.D{
+			{Key: "renameCollection", Value: fmt.Sprintf("%s.%s", state.Database.ValueString(), state.Name.ValueString())},
+			{Key: "to", Value: fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Name.ValueString())},
+			{Key: "dropTarget", Value: plan.DropTarget.ValueBool()},
+		}
+		err := retry.DoApplied(ctx, r.client, func(ctx context.Context) error {
+			return r.client.Database("admin").RunCommand(ctx, cmd).Err()
+		}, namespaceNotFoundCode)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to rename collection", err.Error())
+			return
+		}
+	}
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Name.ValueString()))
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Name.ValueString()))
+
+	// Mutable options are changed in place via collMod
 	db := r.client.Database(plan.Database.ValueString())
 	cmd := bson.D{{Key: "collMod", Value: plan.Name.ValueString()}}
 
-	if plan.TimeSeries != nil && state.TimeSeries != nil {
-		if plan.TimeSeries.ExpireAfterSeconds.ValueInt64() != state.TimeSeries.ExpireAfterSeconds.ValueInt64() {
-			cmd = append(cmd, bson.E{Key: "expireAfterSeconds", Value: plan.TimeSeries.ExpireAfterSeconds.ValueInt64()})
+	if !sameValidator(plan.Validator, state.Validator) || !plan.ValidatorKind.Equal(state.ValidatorKind) {
+		validatorDoc := bson.D{}
+		if v := plan.Validator.ValueString(); v != "" {
+			var err error
+			if validatorDoc, err = buildValidator(v, plan.ValidatorKind.ValueString()); err != nil {
+				resp.Diagnostics.AddError("invalid validator JSON", err.Error())
+				return
+			}
 		}
+		cmd = append(cmd, bson.E{Key: "validator", Value: validatorDoc})
+	}
+	if v := plan.ValidationLevel.ValueString(); v != "" && v != state.ValidationLevel.ValueString() {
+		cmd = append(cmd, bson.E{Key: "validationLevel", Value: v})
+	}
+	if v := plan.ValidationAction.ValueString(); v != "" && v != state.ValidationAction.ValueString() {
+		cmd = append(cmd, bson.E{Key: "validationAction", Value: v})
+	}
 
-		timeseriesSub := bson.D{}
-		if plan.TimeSeries.BucketMaxSpanSeconds.ValueInt64() != state.TimeSeries.BucketMaxSpanSeconds.ValueInt64() {
-			timeseriesSub = append(timeseriesSub, bson.E{Key: "bucketMaxSpanSeconds", Value: plan.TimeSeries.BucketMaxSpanSeconds.ValueInt64()})
+	if plan.ChangeStreamPreAndPostImages.ValueBool() != state.ChangeStreamPreAndPostImages.ValueBool() {
+		cmd = append(cmd, bson.E{Key: "changeStreamPreAndPostImages", Value: bson.D{
+			{Key: "enabled", Value: plan.ChangeStreamPreAndPostImages.ValueBool()},
+		}})
+	}
+
+	if plan.TimeSeries != nil && state.TimeSeries != nil {
+		if !plan.TimeSeries.ExpireAfterSeconds.Equal(state.TimeSeries.ExpireAfterSeconds) {
+			if plan.TimeSeries.ExpireAfterSeconds.IsNull() {
+				// MongoDB removes the TTL of a time-series collection with the literal "off"
+				cmd = append(cmd, bson.E{Key: "expireAfterSeconds", Value: "off"})
+			} else {
+				cmd = append(cmd, bson.E{Key: "expireAfterSeconds", Value: plan.TimeSeries.ExpireAfterSeconds.ValueInt64()})
+			}
 		}
-		if plan.TimeSeries.BucketRoundingSeconds.ValueInt64() != state.TimeSeries.BucketRoundingSeconds.ValueInt64() {
-			timeseriesSub = append(timeseriesSub, bson.E{Key: "bucketRoundingSeconds", Value: plan.TimeSeries.BucketRoundingSeconds.ValueInt64()})
+
+		timeseriesSub, err := timeSeriesUpdate(plan.TimeSeries, state.TimeSeries)
+		if err != nil {
+			resp.Diagnostics.AddError("Unsupported time-series bucketing change", err.Error())
+			return
 		}
 
 		if len(timeseriesSub) > 0 {
@@ -306,7 +672,10 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	// Execute collMod only if we actually have modifications
 	if len(cmd) > 1 {
-		if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+		err := retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return db.RunCommand(ctx, cmd).Err()
+		})
+		if err != nil {
 			resp.Diagnostics.AddError("collMod failed", err.Error())
 			return
 		}
@@ -315,6 +684,32 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// bucketingUpdate returns the collMod timeseries sub-document for custom bucketing changes.
+// MongoDB only accepts both bucketing parameters together, set to the same value, and never decreasing.
+func bucketingUpdate(plan, state *TimeSeriesModel) (bson.D, error) {
+	if plan.BucketMaxSpanSeconds.Equal(state.BucketMaxSpanSeconds) && plan.BucketRoundingSeconds.Equal(state.BucketRoundingSeconds) {
+		return nil, nil
+	}
+
+	if plan.BucketMaxSpanSeconds.IsNull() || plan.BucketRoundingSeconds.IsNull() {
+		return nil, fmt.Errorf("bucket_max_span_seconds and bucket_rounding_seconds must both be set to change custom bucketing; removing them requires recreating the collection")
+	}
+
+	maxSpan := plan.BucketMaxSpanSeconds.ValueInt64()
+	rounding := plan.BucketRoundingSeconds.ValueInt64()
+	if maxSpan != rounding {
+		return nil, fmt.Errorf("bucket_max_span_seconds (%d) and bucket_rounding_seconds (%d) must be equal", maxSpan, rounding)
+	}
+	if !state.BucketMaxSpanSeconds.IsNull() && maxSpan < state.BucketMaxSpanSeconds.ValueInt64() {
+		return nil, fmt.Errorf("bucket_max_span_seconds can only be increased (from %d to %d)", state.BucketMaxSpanSeconds.ValueInt64(), maxSpan)
+	}
+
+	return bson.D{
+		{Key: "bucketMaxSpanSeconds", Value: maxSpan},
+		{Key: "bucketRoundingSeconds", Value: rounding},
+	}, nil
+}
+
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state ResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -322,12 +717,18 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	ctx, cancel := state.Timeouts.DeleteContext(ctx, resourceTimeouts)
+	defer cancel()
+
 	if state.PreventDestroy.ValueBool() {
 		resp.Diagnostics.AddError("Prevented Collection Deletion", "The collection is marked as prevent_destroy, so it will not be deleted.")
 		return
 	}
 
-	if err := r.client.Database(state.Database.ValueString()).Collection(state.Name.ValueString()).Drop(ctx); err != nil {
+	err := retry.Do(ctx, r.client, func(ctx context.Context) error {
+		return r.client.Database(state.Database.ValueString()).Collection(state.Name.ValueString()).Drop(ctx)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError("drop collection failed", err.Error())
 	}
 }
@@ -342,20 +743,28 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		return
 	}
 
-	parts := strings.SplitN(id, "/", 2)
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+	parts, ok := importid.Split(id, 2)
+	if !ok {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			fmt.Sprintf("Expected 'database/collection', got %s", id),
+			fmt.Sprintf("Expected 'database/collection', with any '/' in a name encoded as %%2F, got %s", id),
 		)
 		return
 	}
 	db, coll := parts[0], parts[1]
 
 	var state ResourceModel
-	state.ID = types.StringValue(id)
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s", db, coll))
 	state.Name = types.StringValue(coll)
 	state.Database = types.StringValue(db)
+	state.Namespace = types.StringValue(fmt.Sprintf("%s.%s", db, coll))
+	// Provider-side settings aren't stored on the server; import their defaults so the first plan is clean.
+	// Everything else is filled in by the Read that follows the import.
+	state.PreventDestroy = types.BoolValue(false)
+	state.DropTarget = types.BoolValue(false)
+	state.ValidatorKind = types.StringValue(validatorKindJSONSchema)
+	state.AdoptExisting = types.BoolValue(false)
+	state.AdoptOnlyIfEmpty = types.BoolValue(false)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -0,0 +1,297 @@
+package collection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// plannedModel is the plan for a collection configured with only database and name, before the given fields are
+// set: every attribute holds its default.
+func plannedModel(database, name string) ResourceModel {
+	return ResourceModel{
+		ID:               types.StringValue(database + "/" + name),
+		Database:         types.StringValue(database),
+		Name:             types.StringValue(name),
+		Namespace:        types.StringValue(database + "." + name),
+		PreventDestroy:   types.BoolValue(false),
+		DropTarget:       types.BoolValue(false),
+		AdoptExisting:    types.BoolValue(false),
+		AdoptOnlyIfEmpty: types.BoolValue(false),
+		Validator:        NewValidatorNull(),
+		ValidatorKind:    types.StringValue(validatorKindJSONSchema),
+		ValidationLevel:  types.StringValue(defaultValidationLevel),
+		ValidationAction: types.StringValue(defaultValidationAction),
+	}
+}
+
+// TestImportStateRoundTrip imports collections and checks that the imported state equals the plan for the
+// matching configuration, so the first plan after import has no changes.
+func TestImportStateRoundTrip(t *testing.T) {
+	timeSeriesConfig := plannedModel("db", "events")
+	timeSeriesConfig.TimeSeries = &TimeSeriesModel{
+		TimeField: types.StringValue("ts"),
+		MetaField: types.StringValue("meta"),
+	}
+
+	collationConfig := plannedModel("db", "users")
+	collationConfig.Collation = &CollationModel{
+		Locale:   types.StringValue("en"),
+		Strength: types.Int64Value(2),
+	}
+
+	cases := map[string]struct {
+		id   string
+		spec bson.D
+		want ResourceModel
+	}{
+		"time-series collection": {
+			id: "db/events",
+			spec: collectionSpec("events", bson.D{
+				{Key: "timeseries", Value: bson.D{
+					{Key: "timeField", Value: "ts"},
+					{Key: "metaField", Value: "meta"},
+					{Key: "granularity", Value: "seconds"},
+					{Key: "bucketMaxSpanSeconds", Value: int32(3600)},
+				}},
+			}),
+			want: timeSeriesConfig,
+		},
+		"collection with a collation": {
+			id: "db/users",
+			spec: collectionSpec("users", bson.D{
+				{Key: "collation", Value: bson.D{
+					{Key: "locale", Value: "en"},
+					{Key: "caseLevel", Value: false},
+					{Key: "caseFirst", Value: "off"},
+					{Key: "strength", Value: int32(2)},
+					{Key: "numericOrdering", Value: false},
+					{Key: "alternate", Value: "non-ignorable"},
+					{Key: "maxVariable", Value: "punct"},
+					{Key: "normalization", Value: false},
+					{Key: "backwards", Value: false},
+					{Key: "version", Value: "57.1"},
+				}},
+			}),
+			want: collationConfig,
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(listCollectionsResponse(tc.spec))
+			got := importResource(mt.T, mt.Client, tc.id)
+			if !reflect.DeepEqual(got, tc.want) {
+				mt.Errorf("imported state differs from the configuration\n got: %+v\nwant: %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAdoptOnlyIfEmpty(t *testing.T) {
+	cases := map[string]struct {
+		count   int32
+		wantErr bool
+	}{
+		"empty collection":     {count: 0},
+		"populated collection": {count: 1, wantErr: true},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			countBatch := []bson.D{}
+			if tc.count > 0 {
+				countBatch = append(countBatch, bson.D{{Key: "_id", Value: int32(1)}, {Key: "n", Value: tc.count}})
+			}
+			mt.AddMockResponses(
+				listCollectionsResponse(collectionSpec("events", bson.D{})),
+				mtest.CreateCursorResponse(0, "db.events", mtest.FirstBatch, countBatch...),
+			)
+
+			plan := plannedModel("db", "events")
+			plan.AdoptExisting = types.BoolValue(true)
+			plan.AdoptOnlyIfEmpty = types.BoolValue(true)
+			adopted, err := (&Resource{client: mt.Client}).adopt(context.Background(), plan)
+			if (err != nil) != tc.wantErr {
+				mt.Fatalf("error = %v, want error %t", err, tc.wantErr)
+			}
+			if adopted == tc.wantErr {
+				mt.Errorf("adopted = %t, want %t", adopted, !tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateNoPaddingFailureDropsCollection(t *testing.T) {
+	mt := newMockTest(t)
+	mt.Run("collMod fails", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "storageEngine", Value: bson.D{{Key: "name", Value: "mmapv1"}}}),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Name: "Unauthorized", Message: "not authorized"}),
+			mtest.CreateSuccessResponse(),
+		)
+		plan := plannedModel("db", "events")
+		plan.NoPadding = types.BoolValue(true)
+		resp := createResource(mt.T, mt.Client, plan)
+		if !resp.Diagnostics.HasError() {
+			mt.Fatal("create succeeded although collMod failed")
+		}
+
+		var names []string
+		for _, e := range mt.GetAllStartedEvents() {
+			names = append(names, e.CommandName)
+		}
+		if want := []string{"serverStatus", "create", "collMod", "drop"}; !reflect.DeepEqual(names, want) {
+			mt.Errorf("commands = %v, want %v", names, want)
+		}
+		if !resp.State.Raw.IsNull() {
+			mt.Error("failed collection was saved to state")
+		}
+	})
+}
+
+// TestCappedIdempotent applies a capped collection twice and checks that the second apply finds no changes,
+// although the server rounds size_bytes up to a multiple of 256.
+func TestCappedIdempotent(t *testing.T) {
+	plan := plannedModel("db", "log")
+	plan.Capped = types.BoolValue(true)
+	plan.SizeBytes = types.Int64Value(1000)
+	plan.MaxDocuments = types.Int64Value(50)
+
+	mt := newMockTest(t)
+	mt.Run("apply twice", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		resp := createResource(mt.T, mt.Client, plan)
+		if resp.Diagnostics.HasError() {
+			mt.Fatalf("create: %v", resp.Diagnostics)
+		}
+
+		mt.AddMockResponses(listCollectionsResponse(collectionSpec("log", bson.D{
+			{Key: "capped", Value: true},
+			{Key: "size", Value: int64(1024)},
+			{Key: "max", Value: int64(50)},
+		})))
+		state := readResource(mt.T, mt.Client, stateModel(mt.T, resp.State))
+		if !reflect.DeepEqual(state, plan) {
+			mt.Fatalf("read state %+v differs from plan %+v", state, plan)
+		}
+
+		mt.ClearEvents()
+		if resp := updateResource(mt.T, mt.Client, state, plan); resp.Diagnostics.HasError() {
+			mt.Fatalf("update: %v", resp.Diagnostics)
+		}
+		if events := mt.GetAllStartedEvents(); len(events) > 0 {
+			mt.Errorf("second apply ran %d commands, first was %s", len(events), events[0].CommandName)
+		}
+	})
+}
+
+// TestReadKeepsQueryValidatorKind checks that a query validator made only of $jsonSchema reads back unchanged
+// instead of switching to the jsonSchema kind.
+func TestReadKeepsQueryValidatorKind(t *testing.T) {
+	mt := newMockTest(t)
+	mt.Run("read", func(mt *mtest.T) {
+		prior := plannedModel("db", "users")
+		prior.Validator = NewValidatorValue(`{"$jsonSchema": {"bsonType": "object"}}`)
+		prior.ValidatorKind = types.StringValue(validatorKindQuery)
+
+		mt.AddMockResponses(listCollectionsResponse(collectionSpec("users", bson.D{
+			{Key: "validator", Value: bson.D{{Key: jsonSchemaKey, Value: bson.D{{Key: "bsonType", Value: "object"}}}}},
+		})))
+
+		got := readResource(mt.T, mt.Client, prior)
+		if got.ValidatorKind.ValueString() != validatorKindQuery {
+			mt.Errorf("validator_type = %s, want %s", got.ValidatorKind, validatorKindQuery)
+		}
+		if !sameValidator(got.Validator, prior.Validator) {
+			mt.Errorf("validator = %s, want %s", got.Validator, prior.Validator)
+		}
+	})
+}
+
+// TestTimeSeriesTTL sets expire_after_seconds on create, changes it and removes it, checking the commands sent
+// and the state read back after each step.
+func TestTimeSeriesTTL(t *testing.T) {
+	withTTL := func(ttl int64) ResourceModel {
+		plan := plannedModel("db", "events")
+		plan.TimeSeries = &TimeSeriesModel{
+			TimeField:             types.StringValue("ts"),
+			MetaField:             types.StringNull(),
+			Granularity:           types.StringNull(),
+			BucketMaxSpanSeconds:  types.Int64Null(),
+			BucketRoundingSeconds: types.Int64Null(),
+			ExpireAfterSeconds:    types.Int64Null(),
+		}
+		if ttl != 0 {
+			plan.TimeSeries.ExpireAfterSeconds = types.Int64Value(ttl)
+		}
+		return plan
+	}
+	// spec is the collection as the server reports it with the given TTL, zero for none
+	spec := func(ttl int64) bson.D {
+		options := bson.D{{Key: "timeseries", Value: bson.D{
+			{Key: "timeField", Value: "ts"},
+			{Key: "granularity", Value: "seconds"},
+			{Key: "bucketMaxSpanSeconds", Value: int32(3600)},
+		}}}
+		if ttl != 0 {
+			options = append(options, bson.E{Key: "expireAfterSeconds", Value: ttl})
+		}
+		return collectionSpec("events", options)
+	}
+	// sent returns the expireAfterSeconds of the only command started since the last ClearEvents
+	sent := func(mt *mtest.T, command string) bson.RawValue {
+		events := mt.GetAllStartedEvents()
+		if len(events) != 1 || events[0].CommandName != command {
+			mt.Fatalf("started %d commands, want a single %s", len(events), command)
+		}
+		return events[0].Command.Lookup("expireAfterSeconds")
+	}
+
+	mt := newMockTest(t)
+	mt.Run("lifecycle", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		if resp := createResource(mt.T, mt.Client, withTTL(3600)); resp.Diagnostics.HasError() {
+			mt.Fatalf("create: %v", resp.Diagnostics)
+		}
+		if ttl, ok := sent(mt, "create").AsInt64OK(); !ok || ttl != 3600 {
+			mt.Errorf("create sent expireAfterSeconds %d, want 3600", ttl)
+		}
+
+		mt.AddMockResponses(listCollectionsResponse(spec(3600)))
+		state := readResource(mt.T, mt.Client, withTTL(3600))
+		if !reflect.DeepEqual(state, withTTL(3600)) {
+			mt.Fatalf("read state %+v, want %+v", state.TimeSeries, withTTL(3600).TimeSeries)
+		}
+
+		mt.ClearEvents()
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		if resp := updateResource(mt.T, mt.Client, state, withTTL(7200)); resp.Diagnostics.HasError() {
+			mt.Fatalf("change: %v", resp.Diagnostics)
+		}
+		if ttl, ok := sent(mt, "collMod").AsInt64OK(); !ok || ttl != 7200 {
+			mt.Errorf("collMod sent expireAfterSeconds %d, want 7200", ttl)
+		}
+
+		mt.ClearEvents()
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		if resp := updateResource(mt.T, mt.Client, withTTL(7200), withTTL(0)); resp.Diagnostics.HasError() {
+			mt.Fatalf("remove: %v", resp.Diagnostics)
+		}
+		if off, ok := sent(mt, "collMod").StringValueOK(); !ok || off != "off" {
+			mt.Errorf("collMod sent expireAfterSeconds %q, want \"off\"", off)
+		}
+
+		mt.AddMockResponses(listCollectionsResponse(spec(0)))
+		if state := readResource(mt.T, mt.Client, withTTL(0)); !state.TimeSeries.ExpireAfterSeconds.IsNull() {
+			mt.Errorf("expire_after_seconds = %s after removal, want null", state.TimeSeries.ExpireAfterSeconds)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ListDataSource{}
+var _ datasource.DataSourceWithConfigure = &ListDataSource{}
+
+func NewListDataSource() datasource.DataSource {
+	return &ListDataSource{}
+}
+
+type ListDataSource struct {
+	client *mongo.Client
+}
+
+type collectionEntryModel struct {
+	Name       types.String     `tfsdk:"name"`
+	Type       types.String     `tfsdk:"type"`
+	Capped     types.Bool       `tfsdk:"capped"`
+	TimeSeries *TimeSeriesModel `tfsdk:"timeseries"`
+}
+
+type ListDataSourceModel struct {
+	ID            types.String           `tfsdk:"id"`
+	Database      types.String           `tfsdk:"database"`
+	Type          types.String           `tfsdk:"type"`
+	IncludeSystem types.Bool             `tfsdk:"include_system"`
+	Collections   []collectionEntryModel `tfsdk:"collections"`
+}
+
+func (d *ListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collections"
+}
+
+func (d *ListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the collections in a MongoDB database.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "Database name.",
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "If set, only lists collections of this type. One of 'collection', 'view', or 'timeseries'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("collection", "view", "timeseries"),
+				},
+			},
+			"include_system": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, also lists internal 'system.' collections such as the 'system.buckets.' collections backing time-series collections. Defaults to false.",
+			},
+			"collections": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Collections in the database, in the order the server lists them.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Collection name.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Collection type: 'collection', 'view', or 'timeseries'.",
+						},
+						"capped": schema.BoolAttribute{
+							Computed:    true,
+							Description: "True if the collection is capped.",
+						},
+						"timeseries": schema.SingleNestedAttribute{
+							Computed:    true,
+							Description: "Time-series options; null unless the collection is a time-series collection.",
+							Attributes: map[string]schema.Attribute{
+								"time_field": schema.StringAttribute{
+									Computed:    true,
+									Description: "Name of the field that contains the date in each document.",
+								},
+								"meta_field": schema.StringAttribute{
+									Computed:    true,
+									Description: "Name of the field that contains metadata in each document.",
+								},
+								"granularity": schema.StringAttribute{
+									Computed:    true,
+									Description: "Time-series granularity. One of 'seconds', 'minutes', or 'hours'.",
+								},
+								"bucket_max_span_seconds": schema.Int64Attribute{
+									Computed:    true,
+									Description: "Maximum span (in seconds) for each bucket.",
+								},
+								"bucket_rounding_seconds": schema.Int64Attribute{
+									Computed:    true,
+									Description: "Rounding (in seconds) used to align bucket boundaries.",
+								},
+								"expire_after_seconds": schema.Int64Attribute{
+									Computed:    true,
+									Description: "TTL (in seconds) for time-series collections.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ListDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan ListDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := bson.D{}
+	if !plan.Type.IsNull() {
+		filter = append(filter, bson.E{Key: "type", Value: plan.Type.ValueString()})
+	}
+	specs, err := d.client.Database(plan.Database.ValueString()).ListCollectionSpecifications(ctx, filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing collections",
+			fmt.Sprintf("Failed to list collections: %s", err),
+		)
+		return
+	}
+
+	plan.Collections = make([]collectionEntryModel, 0, len(specs))
+	for _, spec := range specs {
+		if strings.HasPrefix(spec.Name, "system.") && !plan.IncludeSystem.ValueBool() {
+			continue
+		}
+		info, err := decodeSpecification(spec)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to decode collection specification", fmt.Sprintf("%s: %s", spec.Name, err))
+			return
+		}
+		plan.Collections = append(plan.Collections, collectionEntryModel{
+			Name:       types.StringValue(spec.Name),
+			Type:       types.StringValue(spec.Type),
+			Capped:     info.Capped,
+			TimeSeries: info.TimeSeries,
+		})
+	}
+
+	plan.ID = types.StringValue(plan.Database.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
@@ -0,0 +1,68 @@
+package collection
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestListDataSourceHidesSystemCollections(t *testing.T) {
+	cases := map[string]struct {
+		includeSystem types.Bool
+		want          []string
+	}{
+		"default":        {includeSystem: types.BoolNull(), want: []string{"events"}},
+		"include_system": {includeSystem: types.BoolValue(true), want: []string{"events", "system.buckets.events", "system.views"}},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(listCollectionsResponse(
+				collectionSpec("events", bson.D{}),
+				collectionSpec("system.buckets.events", bson.D{}),
+				collectionSpec("system.views", bson.D{}),
+			))
+
+			ctx := context.Background()
+			var schemaResp datasource.SchemaResponse
+			(&ListDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+			s := schemaResp.Schema
+			state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+			config := ListDataSourceModel{
+				ID:            types.StringNull(),
+				Database:      types.StringValue("db"),
+				Type:          types.StringNull(),
+				IncludeSystem: tc.includeSystem,
+			}
+			if diags := state.Set(ctx, &config); diags.HasError() {
+				mt.Fatalf("set config: %v", diags)
+			}
+
+			resp := datasource.ReadResponse{State: state}
+			(&ListDataSource{client: mt.Client}).Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}, &resp)
+			if resp.Diagnostics.HasError() {
+				mt.Fatalf("read: %v", resp.Diagnostics)
+			}
+			var model ListDataSourceModel
+			if diags := resp.State.Get(ctx, &model); diags.HasError() {
+				mt.Fatalf("get state: %v", diags)
+			}
+
+			var names []string
+			for _, c := range model.Collections {
+				names = append(names, c.Name.ValueString())
+			}
+			if !slices.Equal(names, tc.want) {
+				mt.Errorf("collections = %v, want %v", names, tc.want)
+			}
+		})
+	}
+}
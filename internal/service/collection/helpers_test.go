@@ -0,0 +1,137 @@
+package collection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// listCollectionsResponse is a mocked listCollections reply holding the given collection specifications.
+func listCollectionsResponse(specs ...bson.D) bson.D {
+	return mtest.CreateCursorResponse(0, "db.$cmd.listCollections", mtest.FirstBatch, specs...)
+}
+
+// collectionSpec is a listCollections entry for a collection named name with the given options.
+func collectionSpec(name string, options bson.D) bson.D {
+	return bson.D{
+		{Key: "name", Value: name},
+		{Key: "type", Value: "collection"},
+		{Key: "options", Value: options},
+		{Key: "info", Value: bson.D{{Key: "readOnly", Value: false}}},
+	}
+}
+
+func resourceSchema(t *testing.T) resource.SchemaResponse {
+	t.Helper()
+	var resp resource.SchemaResponse
+	(&Resource{}).Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema: %v", resp.Diagnostics)
+	}
+	return resp
+}
+
+// newResourceState returns state holding model, or a null state when model is nil.
+func newResourceState(t *testing.T, model *ResourceModel) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	s := resourceSchema(t).Schema
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if model != nil {
+		if diags := state.Set(ctx, model); diags.HasError() {
+			t.Fatalf("set state: %v", diags)
+		}
+	}
+	return state
+}
+
+func stateModel(t *testing.T, state tfsdk.State) ResourceModel {
+	t.Helper()
+	var model ResourceModel
+	if diags := state.Get(context.Background(), &model); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+	return model
+}
+
+// readResource runs the resource Read against client with prior as the stored state and returns the new state.
+func readResource(t *testing.T, client *mongo.Client, prior ResourceModel) ResourceModel {
+	t.Helper()
+	state := newResourceState(t, &prior)
+	resp := resource.ReadResponse{State: state}
+	(&Resource{client: client}).Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read: %v", resp.Diagnostics)
+	}
+	return stateModel(t, resp.State)
+}
+
+// createResource runs the resource Create for plan and returns the response.
+func createResource(t *testing.T, client *mongo.Client, plan ResourceModel) resource.CreateResponse {
+	t.Helper()
+	planState := newResourceState(t, &plan)
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}
+	resp := resource.CreateResponse{State: newResourceState(t, nil)}
+	(&Resource{client: client}).Create(context.Background(), req, &resp)
+	return resp
+}
+
+// updateResource runs the resource Update from state to plan and returns the response.
+func updateResource(t *testing.T, client *mongo.Client, state, plan ResourceModel) resource.UpdateResponse {
+	t.Helper()
+	planState := newResourceState(t, &plan)
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw},
+		State: newResourceState(t, &state),
+	}
+	resp := resource.UpdateResponse{State: req.State}
+	(&Resource{client: client}).Update(context.Background(), req, &resp)
+	return resp
+}
+
+// importResource runs ImportState for id followed by the Read Terraform performs after an import.
+func importResource(t *testing.T, client *mongo.Client, id string) ResourceModel {
+	t.Helper()
+	resp := resource.ImportStateResponse{State: newResourceState(t, nil)}
+	(&Resource{client: client}).ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("import: %v", resp.Diagnostics)
+	}
+	return readResource(t, client, stateModel(t, resp.State))
+}
+
+// readDataSource runs the data source Read against client for the given configuration.
+func readDataSource(t *testing.T, client *mongo.Client, config DataSourceModel) DataSourceModel {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	(&DataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("set config: %v", diags)
+	}
+	resp := datasource.ReadResponse{State: state}
+	(&DataSource{client: client}).Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read: %v", resp.Diagnostics)
+	}
+
+	var model DataSourceModel
+	if diags := resp.State.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+	return model
+}
+
+func newMockTest(t *testing.T) *mtest.T {
+	return mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+}
@@ -0,0 +1,217 @@
+package collection
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Server defaults for collections that don't report validationLevel/validationAction.
+const (
+	defaultValidationLevel  = "strict"
+	defaultValidationAction = "error"
+)
+
+// Server error codes for a create or rename that an earlier, unacknowledged attempt already applied.
+const (
+	namespaceNotFoundCode = 26
+	namespaceExistsCode   = 48
+)
+
+// collectionInfo is a collection specification decoded into Terraform values.
+// Options the server does not report are null; callers apply their own defaults.
+type collectionInfo struct {
+	Validator        ValidatorValue
+	ValidatorKind    types.String
+	ValidationLevel  types.String
+	ValidationAction types.String
+	NoPadding        types.Bool
+	Capped           types.Bool
+	SizeBytes        types.Int64
+	MaxDocuments     types.Int64
+	Collation        *CollationModel
+
+	ChangeStreamPreAndPostImages types.Bool
+	TimeSeries                   *TimeSeriesModel
+	UUID                         types.String
+	IDIndex                      types.String
+	ReadOnly                     types.Bool
+	WriteConcern                 types.String
+	ReadConcern                  types.String
+}
+
+// decodeSpecification decodes everything the provider reads from a collection specification in one pass.
+func decodeSpecification(spec *mongo.CollectionSpecification) (collectionInfo, error) {
+	info := collectionInfo{
+		ValidationLevel:  types.StringNull(),
+		ValidationAction: types.StringNull(),
+		NoPadding:        types.BoolNull(),
+		UUID:             types.StringNull(),
+		IDIndex:          types.StringNull(),
+		ReadOnly:         types.BoolValue(spec.ReadOnly),
+		Capped:           types.BoolValue(false),
+		SizeBytes:        types.Int64Null(),
+		MaxDocuments:     types.Int64Null(),
+
+		ChangeStreamPreAndPostImages: types.BoolValue(false),
+		WriteConcern:                 types.StringNull(),
+		ReadConcern:                  types.StringNull(),
+	}
+
+	if spec.UUID != nil {
+		b := spec.UUID.Data
+		if len(b) != 16 {
+			return info, fmt.Errorf("invalid collection uuid length %d", len(b))
+		}
+		info.UUID = types.StringValue(fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]))
+	}
+	if spec.IDIndex != nil {
+		info.IDIndex = types.StringValue(spec.IDIndex.Name)
+	}
+
+	validatorValue, kind, err := readValidator(spec.Options)
+	if err != nil {
+		return info, fmt.Errorf("invalid collection validator: %w", err)
+	}
+	info.Validator = validatorValue
+	info.ValidatorKind = types.StringNull()
+	if kind != "" {
+		info.ValidatorKind = types.StringValue(kind)
+	}
+
+	if spec.Options == nil {
+		return info, nil
+	}
+
+	if v, ok := readStringOption(spec.Options, "validationLevel"); ok {
+		info.ValidationLevel = types.StringValue(v)
+	}
+	if v, ok := readStringOption(spec.Options, "validationAction"); ok {
+		info.ValidationAction = types.StringValue(v)
+	}
+	// flags is only reported by MMAPv1
+	if flags, ok := spec.Options.Lookup("flags").AsInt64OK(); ok {
+		info.NoPadding = types.BoolValue(flags&noPaddingFlag != 0)
+	}
+
+	if capped, ok := spec.Options.Lookup("capped").BooleanOK(); ok && capped {
+		info.Capped = types.BoolValue(true)
+		if size, ok := spec.Options.Lookup("size").AsInt64OK(); ok {
+			info.SizeBytes = types.Int64Value(size)
+		}
+		// max is omitted, or reported as 0, when the document count is unbounded
+		if maxDocs, ok := spec.Options.Lookup("max").AsInt64OK(); ok && maxDocs > 0 {
+			info.MaxDocuments = types.Int64Value(maxDocs)
+		}
+	}
+
+	if enabled, ok := spec.Options.Lookup("changeStreamPreAndPostImages", "enabled").BooleanOK(); ok {
+		info.ChangeStreamPreAndPostImages = types.BoolValue(enabled)
+	}
+
+	if v := spec.Options.Lookup("collation"); v.Type == bson.TypeEmbeddedDocument {
+		info.Collation = decodeCollation(v.Document())
+	}
+
+	if info.WriteConcern, err = readDocumentOption(spec.Options, "writeConcern"); err != nil {
+		return info, fmt.Errorf("invalid collection write concern: %w", err)
+	}
+	if info.ReadConcern, err = readDocumentOption(spec.Options, "readConcern"); err != nil {
+		return info, fmt.Errorf("invalid collection read concern: %w", err)
+	}
+
+	if tsVal := spec.Options.Lookup("timeseries"); tsVal.Type == bson.TypeEmbeddedDocument {
+		info.TimeSeries = decodeTimeSeries(tsVal.Document(), spec.Options)
+	}
+
+	return info, nil
+}
+
+// defaultGranularity is the granularity MongoDB reports for time-series collections created without bucketing options.
+const defaultGranularity = "seconds"
+
+// reconcileTimeSeries keeps server-derived time-series values out of the resource state when they
+// weren't configured: the default granularity, and the bucket fields MongoDB derives from a granularity.
+// Without prior state (after import) nothing counts as configured. The data source reports the decoded
+// values as-is.
+func reconcileTimeSeries(prior, current *TimeSeriesModel) {
+	if current == nil {
+		return
+	}
+	if prior == nil {
+		// The zero model is all nulls
+		prior = &TimeSeriesModel{}
+	}
+
+	// Custom bucketing is reported without a granularity, so bucket fields are only derived when one is present
+	if !current.Granularity.IsNull() {
+		if prior.BucketMaxSpanSeconds.IsNull() {
+			current.BucketMaxSpanSeconds = types.Int64Null()
+		}
+		if prior.BucketRoundingSeconds.IsNull() {
+			current.BucketRoundingSeconds = types.Int64Null()
+		}
+	}
+	if prior.Granularity.IsNull() && prior.BucketMaxSpanSeconds.IsNull() && current.Granularity.ValueString() == defaultGranularity {
+		current.Granularity = types.StringNull()
+	}
+}
+
+func decodeTimeSeries(tsDoc bson.Raw, options bson.Raw) *TimeSeriesModel {
+	var tsState TimeSeriesModel
+
+	if f := tsDoc.Lookup("timeField"); f.Type == bson.TypeString {
+		tsState.TimeField = types.StringValue(f.StringValue())
+	} else {
+		tsState.TimeField = types.StringNull()
+	}
+	if f := tsDoc.Lookup("metaField"); f.Type == bson.TypeString {
+		tsState.MetaField = types.StringValue(f.StringValue())
+	} else {
+		tsState.MetaField = types.StringNull()
+	}
+	if f := tsDoc.Lookup("granularity"); f.Type == bson.TypeString {
+		tsState.Granularity = types.StringValue(f.StringValue())
+	} else {
+		tsState.Granularity = types.StringNull()
+	}
+	if value, ok := tsDoc.Lookup("bucketMaxSpanSeconds").AsInt64OK(); ok {
+		tsState.BucketMaxSpanSeconds = types.Int64Value(value)
+	} else {
+		tsState.BucketMaxSpanSeconds = types.Int64Null()
+	}
+	if value, ok := tsDoc.Lookup("bucketRoundingSeconds").AsInt64OK(); ok {
+		tsState.BucketRoundingSeconds = types.Int64Value(value)
+	} else {
+		tsState.BucketRoundingSeconds = types.Int64Null()
+	}
+
+	// TTL lives at the top level of the collection options, not inside timeseries
+	if value, ok := options.Lookup("expireAfterSeconds").AsInt64OK(); ok {
+		tsState.ExpireAfterSeconds = types.Int64Value(value)
+	} else {
+		tsState.ExpireAfterSeconds = types.Int64Null()
+	}
+
+	return &tsState
+}
+
+// readDocumentOption returns an embedded document option as relaxed Extended JSON, or null when absent.
+func readDocumentOption(options bson.Raw, key string) (types.String, error) {
+	val := options.Lookup(key)
+	if val.Type != bson.TypeEmbeddedDocument {
+		return types.StringNull(), nil
+	}
+	extJSON, err := bson.MarshalExtJSON(val.Document(), false, false)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(string(extJSON)), nil
+}
+
+// cappedSize is the size MongoDB stores for a capped collection created with the given size.
+func cappedSize(size int64) int64 {
+	return (size + 255) / 256 * 256
+}
@@ -0,0 +1,162 @@
+package collection
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestReconcileTimeSeries(t *testing.T) {
+	cases := map[string]struct {
+		prior   *TimeSeriesModel
+		current TimeSeriesModel
+		want    TimeSeriesModel
+	}{
+		"import drops the default granularity and derived buckets": {
+			prior: nil,
+			current: TimeSeriesModel{
+				TimeField:             types.StringValue("ts"),
+				Granularity:           types.StringValue("seconds"),
+				BucketMaxSpanSeconds:  types.Int64Value(3600),
+				BucketRoundingSeconds: types.Int64Value(60),
+			},
+			want: TimeSeriesModel{
+				TimeField: types.StringValue("ts"),
+			},
+		},
+		"import keeps a non-default granularity": {
+			prior: nil,
+			current: TimeSeriesModel{
+				TimeField:             types.StringValue("ts"),
+				MetaField:             types.StringValue("meta"),
+				Granularity:           types.StringValue("hours"),
+				BucketMaxSpanSeconds:  types.Int64Value(2592000),
+				BucketRoundingSeconds: types.Int64Value(86400),
+				ExpireAfterSeconds:    types.Int64Value(3600),
+			},
+			want: TimeSeriesModel{
+				TimeField:          types.StringValue("ts"),
+				MetaField:          types.StringValue("meta"),
+				Granularity:        types.StringValue("hours"),
+				ExpireAfterSeconds: types.Int64Value(3600),
+			},
+		},
+		"import keeps custom bucketing": {
+			prior: nil,
+			current: TimeSeriesModel{
+				TimeField:             types.StringValue("ts"),
+				BucketMaxSpanSeconds:  types.Int64Value(7200),
+				BucketRoundingSeconds: types.Int64Value(7200),
+			},
+			want: TimeSeriesModel{
+				TimeField:             types.StringValue("ts"),
+				BucketMaxSpanSeconds:  types.Int64Value(7200),
+				BucketRoundingSeconds: types.Int64Value(7200),
+			},
+		},
+		"configured granularity is kept": {
+			prior: &TimeSeriesModel{
+				TimeField:   types.StringValue("ts"),
+				Granularity: types.StringValue("seconds"),
+			},
+			current: TimeSeriesModel{
+				TimeField:             types.StringValue("ts"),
+				Granularity:           types.StringValue("seconds"),
+				BucketMaxSpanSeconds:  types.Int64Value(3600),
+				BucketRoundingSeconds: types.Int64Value(60),
+			},
+			want: TimeSeriesModel{
+				TimeField:   types.StringValue("ts"),
+				Granularity: types.StringValue("seconds"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.current
+			reconcileTimeSeries(tc.prior, &got)
+			if got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestTimeSeriesReadParity checks that the resource and the data source read the same time-series settings from
+// the same collection. The resource only differs in leaving out bucket fields derived from a granularity.
+func TestTimeSeriesReadParity(t *testing.T) {
+	cases := map[string]struct {
+		options bson.D
+		config  *TimeSeriesModel
+		derived bool
+	}{
+		"custom bucketing with meta field and ttl": {
+			options: bson.D{
+				{Key: "timeseries", Value: bson.D{
+					{Key: "timeField", Value: "ts"},
+					{Key: "metaField", Value: "meta"},
+					{Key: "bucketMaxSpanSeconds", Value: int32(7200)},
+					{Key: "bucketRoundingSeconds", Value: int32(7200)},
+				}},
+				{Key: "expireAfterSeconds", Value: int64(86400)},
+			},
+			config: &TimeSeriesModel{
+				TimeField:             types.StringValue("ts"),
+				MetaField:             types.StringValue("meta"),
+				BucketMaxSpanSeconds:  types.Int64Value(7200),
+				BucketRoundingSeconds: types.Int64Value(7200),
+				ExpireAfterSeconds:    types.Int64Value(86400),
+			},
+		},
+		"granularity without meta field": {
+			options: bson.D{
+				{Key: "timeseries", Value: bson.D{
+					{Key: "timeField", Value: "ts"},
+					{Key: "granularity", Value: "minutes"},
+					{Key: "bucketMaxSpanSeconds", Value: int32(86400)},
+				}},
+			},
+			config: &TimeSeriesModel{
+				TimeField:   types.StringValue("ts"),
+				Granularity: types.StringValue("minutes"),
+			},
+			derived: true,
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(listCollectionsResponse(collectionSpec("events", tc.options)))
+			state := readResource(mt.T, mt.Client, ResourceModel{
+				Database:   types.StringValue("db"),
+				Name:       types.StringValue("events"),
+				TimeSeries: tc.config,
+			})
+
+			mt.AddMockResponses(listCollectionsResponse(collectionSpec("events", tc.options)))
+			data := readDataSource(mt.T, mt.Client, DataSourceModel{
+				Database: types.StringValue("db"),
+				Name:     types.StringValue("events"),
+			})
+
+			if state.TimeSeries == nil || data.TimeSeries == nil {
+				mt.Fatalf("timeseries not read: resource %v, data source %v", state.TimeSeries, data.TimeSeries)
+			}
+			want := *data.TimeSeries
+			if tc.derived {
+				if data.TimeSeries.BucketMaxSpanSeconds.IsNull() {
+					mt.Errorf("data source dropped the derived bucket_max_span_seconds")
+				}
+				want.BucketMaxSpanSeconds = types.Int64Null()
+				want.BucketRoundingSeconds = types.Int64Null()
+			}
+			if *state.TimeSeries != want {
+				mt.Errorf("resource read %+v, data source read %+v", *state.TimeSeries, *data.TimeSeries)
+			}
+		})
+	}
+}
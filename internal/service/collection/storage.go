@@ -0,0 +1,28 @@
+package collection
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	mmapv1Engine = "mmapv1"
+
+	// noPaddingFlag is the bit set in the collection "flags" option when noPadding is enabled.
+	noPaddingFlag = 2
+)
+
+// storageEngine returns the name of the storage engine the server is running.
+func storageEngine(ctx context.Context, client *mongo.Client) (string, error) {
+	var status struct {
+		StorageEngine struct {
+			Name string `bson:"name"`
+		} `bson:"storageEngine"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "serverStatus", Value: 1}}).Decode(&status); err != nil {
+		return "", err
+	}
+	return status.StorageEngine.Name, nil
+}
@@ -0,0 +1,61 @@
+package collection
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// granularityRank orders granularities; collMod can only move a collection to a coarser one.
+var granularityRank = map[string]int{"seconds": 0, "minutes": 1, "hours": 2}
+
+// granularityRequiresReplace replaces the collection when granularity becomes finer, is removed,
+// or replaces custom bucketing, none of which collMod supports.
+func granularityRequiresReplace(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() && req.PlanValue.IsNull() {
+		return
+	}
+	if req.PlanValue.IsNull() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	var stateMaxSpan types.Int64
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeseries").AtName("bucket_max_span_seconds"), &stateMaxSpan)...)
+	if req.StateValue.IsNull() && !stateMaxSpan.IsNull() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	// A null granularity without custom bucketing is the server default, "seconds"
+	from := granularityRank[defaultGranularity]
+	if !req.StateValue.IsNull() {
+		from = granularityRank[req.StateValue.ValueString()]
+	}
+	resp.RequiresReplace = granularityRank[req.PlanValue.ValueString()] < from
+}
+
+// bucketRequiresReplace replaces the collection when a custom bucketing value is removed or decreased.
+func bucketRequiresReplace(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() {
+		return
+	}
+	resp.RequiresReplace = req.PlanValue.IsNull() || req.PlanValue.ValueInt64() < req.StateValue.ValueInt64()
+}
+
+// timeSeriesUpdate returns the collMod timeseries sub-document for granularity or custom bucketing changes.
+func timeSeriesUpdate(plan, state *TimeSeriesModel) (bson.D, error) {
+	if !plan.Granularity.IsNull() && !plan.Granularity.Equal(state.Granularity) {
+		return bson.D{{Key: "granularity", Value: plan.Granularity.ValueString()}}, nil
+	}
+	if !plan.Granularity.IsNull() {
+		// Bucket fields are derived from the granularity
+		return nil, nil
+	}
+	return bucketingUpdate(plan, state)
+}
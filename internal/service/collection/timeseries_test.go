@@ -0,0 +1,127 @@
+package collection
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// bucketing is a time-series model with custom bucketing; zero values are null.
+func bucketing(maxSpan, rounding int64) *TimeSeriesModel {
+	ts := &TimeSeriesModel{
+		TimeField:             types.StringValue("ts"),
+		Granularity:           types.StringNull(),
+		BucketMaxSpanSeconds:  types.Int64Null(),
+		BucketRoundingSeconds: types.Int64Null(),
+	}
+	if maxSpan != 0 {
+		ts.BucketMaxSpanSeconds = types.Int64Value(maxSpan)
+	}
+	if rounding != 0 {
+		ts.BucketRoundingSeconds = types.Int64Value(rounding)
+	}
+	return ts
+}
+
+func TestBucketingUpdate(t *testing.T) {
+	cases := map[string]struct {
+		plan, state *TimeSeriesModel
+		want        bson.D
+		wantErr     bool
+	}{
+		"unchanged": {
+			plan:  bucketing(3600, 3600),
+			state: bucketing(3600, 3600),
+		},
+		"increased": {
+			plan:  bucketing(7200, 7200),
+			state: bucketing(3600, 3600),
+			want:  bson.D{{Key: "bucketMaxSpanSeconds", Value: int64(7200)}, {Key: "bucketRoundingSeconds", Value: int64(7200)}},
+		},
+		"set from granularity": {
+			plan:  bucketing(3600, 3600),
+			state: bucketing(0, 0),
+			want:  bson.D{{Key: "bucketMaxSpanSeconds", Value: int64(3600)}, {Key: "bucketRoundingSeconds", Value: int64(3600)}},
+		},
+		"decreased": {
+			plan:    bucketing(1800, 1800),
+			state:   bucketing(3600, 3600),
+			wantErr: true,
+		},
+		"not equal": {
+			plan:    bucketing(7200, 3600),
+			state:   bucketing(3600, 3600),
+			wantErr: true,
+		},
+		"only max span": {
+			plan:    bucketing(7200, 0),
+			state:   bucketing(3600, 3600),
+			wantErr: true,
+		},
+		"removed": {
+			plan:    bucketing(0, 0),
+			state:   bucketing(3600, 3600),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := bucketingUpdate(tc.plan, tc.state)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("bucketingUpdate = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeSeriesUpdate(t *testing.T) {
+	withGranularity := func(ts *TimeSeriesModel, granularity string) *TimeSeriesModel {
+		ts.Granularity = types.StringValue(granularity)
+		return ts
+	}
+
+	cases := map[string]struct {
+		plan, state *TimeSeriesModel
+		want        bson.D
+	}{
+		"coarser granularity": {
+			plan:  withGranularity(bucketing(0, 0), "hours"),
+			state: withGranularity(bucketing(0, 0), "minutes"),
+			want:  bson.D{{Key: "granularity", Value: "hours"}},
+		},
+		// Bucket values derived from the granularity aren't sent
+		"same granularity": {
+			plan:  withGranularity(bucketing(0, 0), "minutes"),
+			state: withGranularity(bucketing(86400, 0), "minutes"),
+		},
+		"custom bucketing": {
+			plan:  bucketing(7200, 7200),
+			state: bucketing(3600, 3600),
+			want:  bson.D{{Key: "bucketMaxSpanSeconds", Value: int64(7200)}, {Key: "bucketRoundingSeconds", Value: int64(7200)}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := timeSeriesUpdate(tc.plan, tc.state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("timeSeriesUpdate = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package collection
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const jsonSchemaKey = "$jsonSchema"
+
+// Values of validator_type: a $jsonSchema body, or a query-expression validator passed through as-is.
+const (
+	validatorKindJSONSchema = "jsonSchema"
+	validatorKindQuery      = "query"
+)
+
+// buildValidator parses the validator JSON and, for the jsonSchema kind, wraps it as a $jsonSchema document.
+func buildValidator(validator, kind string) (bson.D, error) {
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON([]byte(validator), false, &doc); err != nil {
+		return nil, err
+	}
+	if kind == validatorKindQuery {
+		return doc, nil
+	}
+	return bson.D{{Key: jsonSchemaKey, Value: doc}}, nil
+}
+
+// readValidator converts the validator stored in the collection options back into its JSON form and kind.
+// A $jsonSchema validator is unwrapped; any other (query-expression) validator is surfaced as-is.
+func readValidator(options bson.Raw) (ValidatorValue, string, error) {
+	if options == nil {
+		return NewValidatorNull(), "", nil
+	}
+
+	v := options.Lookup("validator")
+	if v.Type != bson.TypeEmbeddedDocument {
+		return NewValidatorNull(), "", nil
+	}
+
+	doc := v.Document()
+	elems, err := doc.Elements()
+	if err != nil {
+		return NewValidatorNull(), "", err
+	}
+	if len(elems) == 0 {
+		return NewValidatorNull(), "", nil
+	}
+	kind := validatorKindQuery
+	if len(elems) == 1 && elems[0].Key() == jsonSchemaKey && elems[0].Value().Type == bson.TypeEmbeddedDocument {
+		doc = elems[0].Value().Document()
+		kind = validatorKindJSONSchema
+	}
+
+	extJSON, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return NewValidatorNull(), "", err
+	}
+	return NewValidatorValue(string(extJSON)), kind, nil
+}
+
+// asQueryValidator rewraps a $jsonSchema body read back by readValidator as the query-expression validator it was
+// configured as, so a query validator consisting only of $jsonSchema keeps its kind.
+func asQueryValidator(body ValidatorValue) ValidatorValue {
+	if body.IsNull() || body.IsUnknown() {
+		return body
+	}
+	return NewValidatorValue(`{"` + jsonSchemaKey + `":` + body.ValueString() + `}`)
+}
+
+// readStringOption returns the string option at key and whether it is present.
+func readStringOption(options bson.Raw, key string) (string, bool) {
+	if options == nil {
+		return "", false
+	}
+	if v := options.Lookup(key); v.Type == bson.TypeString {
+		return v.StringValue(), true
+	}
+	return "", false
+}
@@ -0,0 +1,78 @@
+package collection
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestValidatorRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		validator string
+		kind      string
+		wantKind  string
+	}{
+		"json schema": {
+			validator: `{"bsonType": "object", "required": ["name"]}`,
+			kind:      validatorKindJSONSchema,
+			wantKind:  validatorKindJSONSchema,
+		},
+		"query expression": {
+			validator: `{"status": {"$in": ["active", "inactive"]}, "age": {"$gte": 0}}`,
+			kind:      validatorKindQuery,
+			wantKind:  validatorKindQuery,
+		},
+		"query expression with json schema": {
+			validator: `{"$jsonSchema": {"bsonType": "object"}, "status": {"$exists": true}}`,
+			kind:      validatorKindQuery,
+			wantKind:  validatorKindQuery,
+		},
+		// Indistinguishable from the jsonSchema kind on the server; Read keeps the configured kind
+		"query expression of only json schema": {
+			validator: `{"$jsonSchema": {"bsonType": "object"}}`,
+			kind:      validatorKindQuery,
+			wantKind:  validatorKindJSONSchema,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			doc, err := buildValidator(tc.validator, tc.kind)
+			if err != nil {
+				t.Fatal(err)
+			}
+			options, err := bson.Marshal(bson.D{{Key: "validator", Value: doc}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, kind, err := readValidator(options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kind != tc.wantKind {
+				t.Fatalf("kind = %q, want %q", kind, tc.wantKind)
+			}
+			if tc.kind != kind {
+				got = asQueryValidator(got)
+			}
+			if !sameValidator(got, NewValidatorValue(tc.validator)) {
+				t.Errorf("validator = %s, want %s", got.ValueString(), tc.validator)
+			}
+		})
+	}
+}
+
+func TestReadValidatorEmpty(t *testing.T) {
+	options, err := bson.Marshal(bson.D{{Key: "validator", Value: bson.D{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, kind, err := readValidator(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsNull() || kind != "" {
+		t.Errorf("readValidator = %s, %q, want null", got, kind)
+	}
+}
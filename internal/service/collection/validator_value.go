@@ -0,0 +1,212 @@
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = ValidatorType{}
+var _ basetypes.StringValuableWithSemanticEquals = ValidatorValue{}
+
+// ValidatorType is a JSON string type whose values compare equal when they describe
+// the same validator, even after MongoDB reformats the stored document.
+type ValidatorType struct {
+	jsontypes.NormalizedType
+}
+
+func (t ValidatorType) String() string {
+	return "collection.ValidatorType"
+}
+
+func (t ValidatorType) ValueType(_ context.Context) attr.Value {
+	return ValidatorValue{}
+}
+
+func (t ValidatorType) Equal(o attr.Type) bool {
+	other, ok := o.(ValidatorType)
+	if !ok {
+		return false
+	}
+	return t.NormalizedType.Equal(other.NormalizedType)
+}
+
+func (t ValidatorType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return ValidatorValue{Normalized: jsontypes.Normalized{StringValue: in}}, nil
+}
+
+func (t ValidatorType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// ValidatorValue holds a validator JSON document.
+type ValidatorValue struct {
+	jsontypes.Normalized
+}
+
+func (v ValidatorValue) Type(_ context.Context) attr.Type {
+	return ValidatorType{}
+}
+
+func (v ValidatorValue) Equal(o attr.Value) bool {
+	other, ok := o.(ValidatorValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals keeps the prior (server-normalized) value whenever both documents
+// describe the same validator once normalizeValidator has been applied to each.
+func (v ValidatorValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(ValidatorValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	equal, err := validatorsEqual(v.ValueString(), newValue.ValueString())
+	if err != nil {
+		// Invalid JSON is reported by attribute validation
+		return false, diags
+	}
+	return equal, diags
+}
+
+func NewValidatorNull() ValidatorValue {
+	return ValidatorValue{Normalized: jsontypes.NewNormalizedNull()}
+}
+
+func NewValidatorValue(value string) ValidatorValue {
+	return ValidatorValue{Normalized: jsontypes.NewNormalizedValue(value)}
+}
+
+// sameValidator reports whether two validator values describe the same validator,
+// so cosmetic JSON differences don't trigger a collMod.
+func sameValidator(a, b ValidatorValue) bool {
+	if a.IsNull() || b.IsNull() {
+		return a.IsNull() == b.IsNull()
+	}
+	equal, err := validatorsEqual(a.ValueString(), b.ValueString())
+	return err == nil && equal
+}
+
+// validatorsEqual reports whether two validator JSON documents are equivalent.
+func validatorsEqual(a, b string) (bool, error) {
+	var docA, docB interface{}
+	if err := json.Unmarshal([]byte(a), &docA); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(b), &docB); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(normalizeValidator(docA), normalizeValidator(docB)), nil
+}
+
+// normalizeValidator rewrites the forms MongoDB treats as equivalent into a single canonical form:
+// a one-element bsonType/type array becomes the bare type name, and type lists and
+// "required" field lists are sorted since their order carries no meaning.
+func normalizeValidator(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			switch k {
+			case "bsonType", "type":
+				out[k] = normalizeTypeList(item)
+			case "required":
+				out[k] = sortedStrings(item)
+			case "properties", "patternProperties":
+				// Keys here are field names, not keywords, so a field named "type" keeps its schema intact
+				out[k] = normalizeProperties(item)
+			default:
+				out[k] = normalizeValidator(item)
+			}
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = normalizeValidator(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// normalizeProperties normalizes each field schema of a properties map without reading the field names
+// as keywords.
+func normalizeProperties(v interface{}) interface{} {
+	props, ok := v.(map[string]interface{})
+	if !ok {
+		return normalizeValidator(v)
+	}
+	out := make(map[string]interface{}, len(props))
+	for name, schema := range props {
+		out[name] = normalizeValidator(schema)
+	}
+	return out
+}
+
+func normalizeTypeList(v interface{}) interface{} {
+	list, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+	if len(list) == 1 {
+		return list[0]
+	}
+	return sortedStrings(list)
+}
+
+func sortedStrings(v interface{}) interface{} {
+	list, ok := v.([]interface{})
+	if !ok {
+		return normalizeValidator(v)
+	}
+
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return normalizeValidator(v)
+		}
+		strs = append(strs, s)
+	}
+	sort.Strings(strs)
+
+	out := make([]interface{}, len(strs))
+	for i, s := range strs {
+		out[i] = s
+	}
+	return out
+}
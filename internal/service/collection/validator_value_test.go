@@ -0,0 +1,141 @@
+package collection
+
+import (
+	"testing"
+)
+
+func TestValidatorsEqual(t *testing.T) {
+	cases := map[string]struct {
+		a, b string
+		want bool
+	}{
+		"reordered keys": {
+			a:    `{"bsonType": "object", "required": ["name"], "properties": {"name": {"bsonType": "string"}}}`,
+			b:    `{"properties": {"name": {"bsonType": "string"}}, "required": ["name"], "bsonType": "object"}`,
+			want: true,
+		},
+		"single-element bsonType array": {
+			a:    `{"bsonType": ["object"]}`,
+			b:    `{"bsonType": "object"}`,
+			want: true,
+		},
+		"single-element type array": {
+			a:    `{"type": ["string"]}`,
+			b:    `{"type": "string"}`,
+			want: true,
+		},
+		"type list order": {
+			a:    `{"bsonType": ["string", "null"]}`,
+			b:    `{"bsonType": ["null", "string"]}`,
+			want: true,
+		},
+		"required order": {
+			a:    `{"required": ["name", "email", "age"]}`,
+			b:    `{"required": ["age", "name", "email"]}`,
+			want: true,
+		},
+		"different required": {
+			a:    `{"required": ["name", "email"]}`,
+			b:    `{"required": ["name"]}`,
+			want: false,
+		},
+		"different type": {
+			a:    `{"bsonType": ["string", "null"]}`,
+			b:    `{"bsonType": "string"}`,
+			want: false,
+		},
+		"array order elsewhere matters": {
+			a:    `{"$or": [{"a": 1}, {"b": 2}]}`,
+			b:    `{"$or": [{"b": 2}, {"a": 1}]}`,
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := validatorsEqual(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("validatorsEqual = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidatorsEqualInvalidJSON(t *testing.T) {
+	if _, err := validatorsEqual(`{"bsonType":`, `{}`); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestSameValidator(t *testing.T) {
+	cases := map[string]struct {
+		a, b ValidatorValue
+		want bool
+	}{
+		"both null":         {a: NewValidatorNull(), b: NewValidatorNull(), want: true},
+		"null and value":    {a: NewValidatorNull(), b: NewValidatorValue(`{}`), want: false},
+		"equivalent values": {a: NewValidatorValue(`{"bsonType": ["object"]}`), b: NewValidatorValue(`{"bsonType": "object"}`), want: true},
+		"invalid JSON":      {a: NewValidatorValue(`{`), b: NewValidatorValue(`{`), want: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := sameValidator(tc.a, tc.b); got != tc.want {
+				t.Errorf("sameValidator = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeValidatorProperties(t *testing.T) {
+	cases := map[string]struct {
+		a, b string
+		want bool
+	}{
+		"property named type": {
+			a:    `{"properties": {"type": {"bsonType": ["string"], "enum": ["a", "b"]}}}`,
+			b:    `{"properties": {"type": {"bsonType": "string", "enum": ["a", "b"]}}}`,
+			want: true,
+		},
+		"property named required": {
+			a:    `{"properties": {"required": {"bsonType": ["bool"]}}, "required": ["required", "name"]}`,
+			b:    `{"properties": {"required": {"bsonType": "bool"}}, "required": ["name", "required"]}`,
+			want: true,
+		},
+		"property named type with a different schema": {
+			a:    `{"properties": {"type": {"bsonType": "string"}}}`,
+			b:    `{"properties": {"type": {"bsonType": "int"}}}`,
+			want: false,
+		},
+		"nested properties": {
+			a:    `{"properties": {"address": {"properties": {"type": {"bsonType": ["string", "null"]}}}}}`,
+			b:    `{"properties": {"address": {"properties": {"type": {"bsonType": ["null", "string"]}}}}}`,
+			want: true,
+		},
+		"pattern properties": {
+			a:    `{"patternProperties": {"^required": {"required": ["b", "a"]}}}`,
+			b:    `{"patternProperties": {"^required": {"required": ["a", "b"]}}}`,
+			want: true,
+		},
+		"enum order matters": {
+			a:    `{"properties": {"type": {"enum": ["a", "b"]}}}`,
+			b:    `{"properties": {"type": {"enum": ["b", "a"]}}}`,
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := validatorsEqual(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("validatorsEqual = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CollationModel struct {
+	Locale          types.String `tfsdk:"locale"`
+	Strength        types.Int64  `tfsdk:"strength"`
+	CaseLevel       types.Bool   `tfsdk:"case_level"`
+	CaseFirst       types.String `tfsdk:"case_first"`
+	NumericOrdering types.Bool   `tfsdk:"numeric_ordering"`
+}
+
+func collationBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Collation for the placeholder collection. Only applied when the placeholder is created; changes don't affect an existing placeholder.",
+		Attributes: map[string]schema.Attribute{
+			"locale": schema.StringAttribute{
+				Optional:    true,
+				Description: "ICU locale, e.g. 'en' or 'fr_CA'. Required when the block is set.",
+			},
+			"strength": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Comparison level, 1 through 5.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 5),
+				},
+			},
+			"case_level": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, includes case comparison at strength 1 or 2.",
+			},
+			"case_first": schema.StringAttribute{
+				Optional:    true,
+				Description: "Sort order of case differences. One of 'upper', 'lower', or 'off'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("upper", "lower", "off"),
+				},
+			},
+			"numeric_ordering": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, compares numeric strings as numbers.",
+			},
+		},
+	}
+}
+
+// toOptions converts the collation block into driver options; a nil block means server defaults.
+func (c *CollationModel) toOptions() (*options.Collation, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if c.Locale.ValueString() == "" {
+		return nil, fmt.Errorf("locale is required when a collation is set")
+	}
+
+	collation := &options.Collation{
+		Locale:          c.Locale.ValueString(),
+		Strength:        int(c.Strength.ValueInt64()),
+		CaseLevel:       c.CaseLevel.ValueBool(),
+		CaseFirst:       c.CaseFirst.ValueString(),
+		NumericOrdering: c.NumericOrdering.ValueBool(),
+	}
+	return collation, nil
+}
@@ -3,12 +3,10 @@ package database
 import (
 	"context"
 	"fmt"
-	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -28,6 +26,7 @@ type DataSourceModel struct {
 	ID              types.String `tfsdk:"id"`
 	Name            types.String `tfsdk:"name"`
 	KeepPlaceholder types.Bool   `tfsdk:"keep_placeholder"`
+	PlaceholderName types.String `tfsdk:"placeholder_name"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -49,6 +48,10 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Computed:    true,
 				Description: "Keep a tiny placeholder collection so the DB persists. (Default: true)",
 			},
+			"placeholder_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the placeholder collection to look for. (Default: __tf_placeholder)",
+			},
 		},
 	}
 }
@@ -79,22 +82,29 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
-	db := d.client.Database(plan.Name.ValueString())
-	names, err := db.ListCollectionNames(ctx, bson.D{})
+	exists, err := databaseExists(ctx, d.client, plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error read database", "list collections failed: "+err.Error(),
+			"Error read database", "list databases failed: "+err.Error(),
 		)
 		return
 	}
-	if len(names) == 0 {
+	if !exists {
 		resp.Diagnostics.AddError(
 			"Error read database", "not found",
 		)
 		return
 	}
 
+	hasPlaceholder, err := hasCollection(ctx, d.client.Database(plan.Name.ValueString()), placeholderName(plan.PlaceholderName))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error read database", "list collections failed: "+err.Error(),
+		)
+		return
+	}
+
 	plan.ID = types.StringValue(plan.Name.ValueString())
-	plan.KeepPlaceholder = types.BoolValue(slices.Contains(names, tfPlaceholderColl))
+	plan.KeepPlaceholder = types.BoolValue(hasPlaceholder)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
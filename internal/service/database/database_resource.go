@@ -3,26 +3,46 @@ package database
 import (
 	"context"
 	"fmt"
-	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/datafy-io/terraform-provider-mongodb/internal/retry"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// tfPlaceholderColl is the default name of the placeholder collection.
 const tfPlaceholderColl = "__tf_placeholder"
 
+const (
+	deleteBehaviorDropDatabase        = "drop_database"
+	deleteBehaviorDropPlaceholderOnly = "drop_placeholder_only"
+)
+
 // Ensure implementation satisfies interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithConfigure = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
 
+// resourceTimeouts are the operation time limits used without a timeouts block.
+var resourceTimeouts = timeouts.Defaults{
+	Create: 10 * time.Minute,
+	Update: 10 * time.Minute,
+	Delete: 10 * time.Minute,
+}
+
 func NewResource() resource.Resource {
 	return &Resource{}
 }
@@ -35,7 +55,22 @@ type ResourceModel struct {
 	ID              types.String `tfsdk:"id"`
 	Name            types.String `tfsdk:"name"`
 	KeepPlaceholder types.Bool   `tfsdk:"keep_placeholder"`
+	PlaceholderName types.String `tfsdk:"placeholder_name"`
 	PreventDestroy  types.Bool   `tfsdk:"prevent_destroy"`
+	ForceDestroy    types.Bool   `tfsdk:"force_destroy"`
+	DeleteBehavior  types.String `tfsdk:"delete_behavior"`
+
+	PlaceholderCollation *CollationModel `tfsdk:"placeholder_collation"`
+
+	Timeouts *timeouts.Model `tfsdk:"timeouts"`
+}
+
+// placeholderName returns the configured placeholder name, or the default for state written before it existed.
+func placeholderName(v types.String) string {
+	if v.ValueString() == "" {
+		return tfPlaceholderColl
+	}
+	return v.ValueString()
 }
 
 func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,12 +100,40 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Default:     booldefault.StaticBool(true),
 				Description: "Keep a tiny placeholder collection so the DB persists. (Default: true)",
 			},
+			"placeholder_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(tfPlaceholderColl),
+				Description: "Name of the placeholder collection. Changing it renames an existing placeholder in place. (Default: __tf_placeholder)",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"prevent_destroy": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 				Description: "If true, prevents the database from being destroyed. (Default: false)",
 			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "If false, destroying the database fails while it holds collections other than the placeholder, so data created outside Terraform isn't dropped with it. (Default: true)",
+			},
+			"delete_behavior": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(deleteBehaviorDropDatabase),
+				Description: "What to remove on destroy: 'drop_database' drops the whole database, 'drop_placeholder_only' only drops the placeholder collection and leaves other collections intact. (Default: drop_database)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(deleteBehaviorDropDatabase, deleteBehaviorDropPlaceholderOnly),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"placeholder_collation": collationBlock(),
+			"timeouts":              timeouts.Block(resourceTimeouts),
 		},
 	}
 }
@@ -100,6 +163,9 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	ctx, cancel := plan.Timeouts.CreateContext(ctx, resourceTimeouts)
+	defer cancel()
+
 	dbs, err := r.client.ListDatabaseNames(ctx, bson.D{{Key: "name", Value: plan.Name.ValueString()}})
 	if err != nil {
 		resp.Diagnostics.AddError("List databases failed", err.Error())
@@ -116,8 +182,18 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	db := r.client.Database(plan.Name.ValueString())
 
 	if plan.KeepPlaceholder.ValueBool() {
-		// create placeholder collection (ignore if exists)
-		_ = db.RunCommand(ctx, bson.D{{Key: "create", Value: tfPlaceholderColl}}).Err()
+		collation, err := plan.PlaceholderCollation.toOptions()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("placeholder_collation"), "Invalid placeholder collation", err.Error())
+			return
+		}
+		err = retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return ensurePlaceholder(ctx, db, plan.PlaceholderName.ValueString(), collation)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("create placeholder failed", err.Error())
+			return
+		}
 	}
 
 	plan.ID = types.StringValue(plan.Name.ValueString())
@@ -131,37 +207,81 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	db := r.client.Database(state.Name.ValueString())
-	names, err := db.ListCollectionNames(ctx, bson.D{})
+	exists, err := databaseExists(ctx, r.client, state.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("list collections failed", err.Error())
+		resp.Diagnostics.AddError("list databases failed", err.Error())
 		return
 	}
-	if len(names) == 0 {
+	if !exists {
 		// DB likely gone
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	placeholder := placeholderName(state.PlaceholderName)
+	hasPlaceholder, err := hasCollection(ctx, r.client.Database(state.Name.ValueString()), placeholder)
+	if err != nil {
+		resp.Diagnostics.AddError("list collections failed", err.Error())
+		return
+	}
+
+	// User collections stand in for the placeholder, so a wanted placeholder isn't drift
+	if !hasPlaceholder && state.KeepPlaceholder.ValueBool() {
+		hasUser, err := hasUserCollections(ctx, r.client.Database(state.Name.ValueString()), placeholder)
+		if err != nil {
+			resp.Diagnostics.AddError("list collections failed", err.Error())
+			return
+		}
+		hasPlaceholder = hasUser
+	}
+
 	state.ID = types.StringValue(state.Name.ValueString())
-	state.KeepPlaceholder = types.BoolValue(slices.Contains(names, tfPlaceholderColl))
+	state.KeepPlaceholder = types.BoolValue(hasPlaceholder)
+	state.PlaceholderName = types.StringValue(placeholder)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// No updatable attributes (name is ForceNew semantically). We just refresh state.
-	var plan ResourceModel
+	// name is ForceNew; only the placeholder is reconciled here
+	var plan, state ResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := plan.Timeouts.UpdateContext(ctx, resourceTimeouts)
+	defer cancel()
+
+	placeholder := plan.PlaceholderName.ValueString()
+	if prior := state.PlaceholderName.ValueString(); prior != "" && prior != placeholder {
+		err := retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return renamePlaceholder(ctx, r.client, plan.Name.ValueString(), prior, placeholder)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("rename placeholder failed", err.Error())
+			return
+		}
+	}
+
 	db := r.client.Database(plan.Name.ValueString())
 	if plan.KeepPlaceholder.ValueBool() {
-		// create placeholder collection (ignore if exists)
-		_ = db.RunCommand(ctx, bson.D{{Key: "create", Value: tfPlaceholderColl}}).Err()
+		collation, err := plan.PlaceholderCollation.toOptions()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("placeholder_collation"), "Invalid placeholder collation", err.Error())
+			return
+		}
+		err = retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return ensurePlaceholder(ctx, db, placeholder, collation)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("create placeholder failed", err.Error())
+			return
+		}
 	} else {
-		_ = db.RunCommand(ctx, bson.D{{Key: "drop", Value: tfPlaceholderColl}}).Err()
+		_ = retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return db.RunCommand(ctx, bson.D{{Key: "drop", Value: placeholder}}).Err()
+		})
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -174,12 +294,45 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	ctx, cancel := state.Timeouts.DeleteContext(ctx, resourceTimeouts)
+	defer cancel()
+
 	if state.PreventDestroy.ValueBool() {
 		resp.Diagnostics.AddError("Prevented Database Deletion", "The database is marked as prevent_destroy, so it will not be deleted.")
 		return
 	}
 
-	if err := r.client.Database(state.Name.ValueString()).Drop(ctx); err != nil {
+	if state.DeleteBehavior.ValueString() == deleteBehaviorDropPlaceholderOnly {
+		err := retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return r.client.Database(state.Name.ValueString()).Collection(placeholderName(state.PlaceholderName)).Drop(ctx)
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("failed to drop placeholder collection", err.Error())
+		}
+		return
+	}
+
+	db := r.client.Database(state.Name.ValueString())
+	if !state.ForceDestroy.IsNull() && !state.ForceDestroy.ValueBool() {
+		names, err := listUserCollections(ctx, db, placeholderName(state.PlaceholderName))
+		if err != nil {
+			resp.Diagnostics.AddError("list collections failed", err.Error())
+			return
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			resp.Diagnostics.AddError(
+				"Database Not Empty",
+				fmt.Sprintf("Database %s still holds collections: %s. Remove them or set force_destroy = true to drop them with the database.", state.Name.ValueString(), strings.Join(names, ", ")),
+			)
+			return
+		}
+	}
+
+	err := retry.Do(ctx, r.client, func(ctx context.Context) error {
+		return db.Drop(ctx)
+	})
+	if err != nil {
 		resp.Diagnostics.AddError("failed to drop database", err.Error())
 	}
 }
@@ -194,6 +347,9 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	var state ResourceModel
 	state.ID = types.StringValue(id)
 	state.Name = types.StringValue(id)
+	state.DeleteBehavior = types.StringValue(deleteBehaviorDropDatabase)
+	state.PlaceholderName = types.StringValue(tfPlaceholderColl)
+	state.ForceDestroy = types.BoolValue(true)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
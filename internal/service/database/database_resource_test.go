@@ -0,0 +1,254 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// newState returns database resource state holding model, or a null state when model is nil.
+func newState(t *testing.T, model *ResourceModel) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&Resource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if model != nil {
+		if diags := state.Set(ctx, model); diags.HasError() {
+			t.Fatalf("set state: %v", diags)
+		}
+	}
+	return state
+}
+
+// databaseModel is the state of the database "db" with the given placeholder and the other attributes at their
+// defaults.
+func databaseModel(placeholder string) ResourceModel {
+	return ResourceModel{
+		ID:              types.StringValue("db"),
+		Name:            types.StringValue("db"),
+		KeepPlaceholder: types.BoolValue(true),
+		PlaceholderName: types.StringValue(placeholder),
+		PreventDestroy:  types.BoolValue(false),
+		ForceDestroy:    types.BoolValue(true),
+		DeleteBehavior:  types.StringValue(deleteBehaviorDropDatabase),
+	}
+}
+
+// collectionNames is a mocked listCollections reply holding the named collections.
+func collectionNames(names ...string) bson.D {
+	var docs []bson.D
+	for _, name := range names {
+		docs = append(docs, bson.D{{Key: "name", Value: name}, {Key: "type", Value: "collection"}})
+	}
+	return mtest.CreateCursorResponse(0, "db.$cmd.listCollections", mtest.FirstBatch, docs...)
+}
+
+// commandNames returns the names of the commands started against the mocked server, in order.
+func commandNames(mt *mtest.T) []string {
+	var names []string
+	for _, e := range mt.GetAllStartedEvents() {
+		names = append(names, e.CommandName)
+	}
+	return names
+}
+
+// startedCommand returns the first started command with the given name, failing the test if there is none.
+func startedCommand(mt *mtest.T, name string) bson.Raw {
+	mt.Helper()
+	for _, e := range mt.GetAllStartedEvents() {
+		if e.CommandName == name {
+			return e.Command
+		}
+	}
+	mt.Fatalf("no %s in %v", name, commandNames(mt))
+	return nil
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		state        func(m *ResourceModel)
+		responses    []bson.D
+		wantCommands []string
+		wantErr      string
+	}{
+		"non-empty database refused": {
+			state:        func(m *ResourceModel) { m.ForceDestroy = types.BoolValue(false) },
+			responses:    []bson.D{collectionNames("orders", "accounts")},
+			wantCommands: []string{"listCollections"},
+			wantErr:      "Database db still holds collections: accounts, orders.",
+		},
+		"empty database dropped": {
+			state:        func(m *ResourceModel) { m.ForceDestroy = types.BoolValue(false) },
+			responses:    []bson.D{collectionNames(), mtest.CreateSuccessResponse()},
+			wantCommands: []string{"listCollections", "dropDatabase"},
+		},
+		"force_destroy": {
+			responses:    []bson.D{mtest.CreateSuccessResponse()},
+			wantCommands: []string{"dropDatabase"},
+		},
+		"force_destroy unset in older state": {
+			state:        func(m *ResourceModel) { m.ForceDestroy = types.BoolNull() },
+			responses:    []bson.D{mtest.CreateSuccessResponse()},
+			wantCommands: []string{"dropDatabase"},
+		},
+		"drop_placeholder_only": {
+			state: func(m *ResourceModel) {
+				m.ForceDestroy = types.BoolValue(false)
+				m.DeleteBehavior = types.StringValue(deleteBehaviorDropPlaceholderOnly)
+			},
+			responses:    []bson.D{mtest.CreateSuccessResponse()},
+			wantCommands: []string{"drop"},
+		},
+		"prevent_destroy": {
+			state:   func(m *ResourceModel) { m.PreventDestroy = types.BoolValue(true) },
+			wantErr: "The database is marked as prevent_destroy",
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			model := databaseModel("_keep")
+			if tc.state != nil {
+				tc.state(&model)
+			}
+			mt.AddMockResponses(tc.responses...)
+
+			req := resource.DeleteRequest{State: newState(mt.T, &model)}
+			resp := resource.DeleteResponse{State: req.State}
+			(&Resource{client: mt.Client}).Delete(context.Background(), req, &resp)
+
+			if tc.wantErr != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), tc.wantErr) {
+					mt.Fatalf("diagnostics = %v, want error containing %q", resp.Diagnostics, tc.wantErr)
+				}
+			} else if resp.Diagnostics.HasError() {
+				mt.Fatalf("delete: %v", resp.Diagnostics)
+			}
+			if got := commandNames(mt); !slices.Equal(got, tc.wantCommands) {
+				mt.Errorf("commands = %v, want %v", got, tc.wantCommands)
+			}
+		})
+	}
+
+	mt.Run("placeholder_name is left out of the emptiness check", func(mt *mtest.T) {
+		model := databaseModel("_keep")
+		model.ForceDestroy = types.BoolValue(false)
+		mt.AddMockResponses(collectionNames(), mtest.CreateSuccessResponse())
+
+		req := resource.DeleteRequest{State: newState(mt.T, &model)}
+		resp := resource.DeleteResponse{State: req.State}
+		(&Resource{client: mt.Client}).Delete(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() {
+			mt.Fatalf("delete: %v", resp.Diagnostics)
+		}
+		filter := startedCommand(mt, "listCollections").Lookup("filter").String()
+		if !strings.Contains(filter, "_keep") {
+			mt.Errorf("listCollections filter %s does not exclude the placeholder", filter)
+		}
+	})
+
+	mt.Run("drop_placeholder_only drops the named placeholder", func(mt *mtest.T) {
+		model := databaseModel("_keep")
+		model.DeleteBehavior = types.StringValue(deleteBehaviorDropPlaceholderOnly)
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		req := resource.DeleteRequest{State: newState(mt.T, &model)}
+		resp := resource.DeleteResponse{State: req.State}
+		(&Resource{client: mt.Client}).Delete(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() {
+			mt.Fatalf("delete: %v", resp.Diagnostics)
+		}
+		if got := startedCommand(mt, "drop").Lookup("drop").StringValue(); got != "_keep" {
+			mt.Errorf("dropped %q, want the placeholder _keep", got)
+		}
+	})
+}
+
+func TestPlaceholderName(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("created under the configured name", func(mt *mtest.T) {
+		plan := databaseModel("_keep")
+		plan.ID = types.StringUnknown()
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "databases", Value: bson.A{}}),
+			collectionNames(),
+			mtest.CreateSuccessResponse(),
+		)
+
+		planState := newState(mt.T, &plan)
+		req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}
+		resp := resource.CreateResponse{State: newState(mt.T, nil)}
+		(&Resource{client: mt.Client}).Create(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() {
+			mt.Fatalf("create: %v", resp.Diagnostics)
+		}
+		if got := startedCommand(mt, "create").Lookup("create").StringValue(); got != "_keep" {
+			mt.Errorf("created %q, want _keep", got)
+		}
+	})
+
+	mt.Run("renamed in place", func(mt *mtest.T) {
+		state, plan := databaseModel("_old"), databaseModel("_new")
+		mt.AddMockResponses(
+			collectionNames("_old"),
+			mtest.CreateSuccessResponse(),
+			collectionNames(),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 48, Name: "NamespaceExists", Message: "collection already exists"}),
+		)
+
+		planState := newState(mt.T, &plan)
+		req := resource.UpdateRequest{
+			Plan:  tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw},
+			State: newState(mt.T, &state),
+		}
+		resp := resource.UpdateResponse{State: req.State}
+		(&Resource{client: mt.Client}).Update(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() {
+			mt.Fatalf("update: %v", resp.Diagnostics)
+		}
+
+		rename := startedCommand(mt, "renameCollection")
+		if from, to := rename.Lookup("renameCollection").StringValue(), rename.Lookup("to").StringValue(); from != "db._old" || to != "db._new" {
+			mt.Errorf("renamed %s to %s, want db._old to db._new", from, to)
+		}
+	})
+
+	mt.Run("defaulted for state without it", func(mt *mtest.T) {
+		prior := databaseModel("")
+		prior.PlaceholderName = types.StringNull()
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "databases", Value: bson.A{bson.D{{Key: "name", Value: "db"}}}}),
+			collectionNames(tfPlaceholderColl),
+		)
+
+		state := newState(mt.T, &prior)
+		resp := resource.ReadResponse{State: state}
+		(&Resource{client: mt.Client}).Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+		if resp.Diagnostics.HasError() {
+			mt.Fatalf("read: %v", resp.Diagnostics)
+		}
+		var model ResourceModel
+		if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+			mt.Fatalf("get state: %v", diags)
+		}
+		if got := model.PlaceholderName.ValueString(); got != tfPlaceholderColl {
+			mt.Errorf("placeholder_name = %q, want %q", got, tfPlaceholderColl)
+		}
+		if !model.KeepPlaceholder.ValueBool() {
+			mt.Errorf("keep_placeholder = false with the placeholder present")
+		}
+	})
+}
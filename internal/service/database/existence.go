@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// databaseExists checks for the database by name without listing its collections,
+// which can be slow on databases with many collections.
+func databaseExists(ctx context.Context, client *mongo.Client, name string) (bool, error) {
+	dbs, err := client.ListDatabaseNames(ctx, bson.D{{Key: "name", Value: name}}, options.ListDatabases().SetNameOnly(true))
+	if err != nil {
+		return false, err
+	}
+	return len(dbs) > 0, nil
+}
+
+// hasCollection checks for a single collection using a name filter.
+func hasCollection(ctx context.Context, db *mongo.Database, name string) (bool, error) {
+	names, err := db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: name}})
+	if err != nil {
+		return false, err
+	}
+	return len(names) > 0, nil
+}
+
+// hasUserCollections reports whether the database holds any collection besides the named placeholder.
+// Internal "system.*" collections (e.g. time-series system.buckets.*) are ignored. Only the
+// first match is fetched, so this stays cheap on databases with many collections.
+func hasUserCollections(ctx context.Context, db *mongo.Database, placeholder string) (bool, error) {
+	cursor, err := db.ListCollections(ctx, userCollectionsFilter(placeholder), options.ListCollections().SetNameOnly(true).SetBatchSize(1))
+	if err != nil {
+		return false, err
+	}
+	defer cursor.Close(ctx)
+
+	return cursor.Next(ctx), cursor.Err()
+}
+
+// listUserCollections returns the names of the collections besides the placeholder and internal ones.
+func listUserCollections(ctx context.Context, db *mongo.Database, placeholder string) ([]string, error) {
+	return db.ListCollectionNames(ctx, userCollectionsFilter(placeholder))
+}
+
+func userCollectionsFilter(placeholder string) bson.D {
+	return bson.D{{Key: "name", Value: bson.D{{Key: "$not", Value: primitive.Regex{
+		Pattern: "^(system\\.|" + regexp.QuoteMeta(placeholder) + "$)",
+	}}}}}
+}
+
+// ensurePlaceholder creates the placeholder collection unless user collections already keep the database alive.
+// A nil collation creates the placeholder with the server defaults.
+func ensurePlaceholder(ctx context.Context, db *mongo.Database, placeholder string, collation *options.Collation) error {
+	hasUser, err := hasUserCollections(ctx, db, placeholder)
+	if err != nil {
+		return err
+	}
+	if hasUser {
+		return nil
+	}
+
+	// create placeholder collection; a concurrent create is fine
+	opts := options.CreateCollection()
+	if collation != nil {
+		opts.SetCollation(collation)
+	}
+	if err := db.CreateCollection(ctx, placeholder, opts); err != nil && !isNamespaceExists(err) {
+		return err
+	}
+	return nil
+}
+
+// namespaceExistsCode is the server error code when the collection to create already exists.
+const namespaceExistsCode = 48
+
+func isNamespaceExists(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == namespaceExistsCode || cmdErr.Name == "NamespaceExists"
+	}
+	return false
+}
+
+// renamePlaceholder moves an existing placeholder to its new name, keeping its options.
+// Nothing happens if there's no placeholder under the old name.
+func renamePlaceholder(ctx context.Context, client *mongo.Client, dbName, from, to string) error {
+	exists, err := hasCollection(ctx, client.Database(dbName), from)
+	if err != nil || !exists {
+		return err
+	}
+
+	cmd := bson.D{
+		{Key: "renameCollection", Value: dbName + "." + from},
+		{Key: "to", Value: dbName + "." + to},
+	}
+	return client.Database("admin").RunCommand(ctx, cmd).Err()
+}
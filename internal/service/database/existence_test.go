@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestEnsurePlaceholder(t *testing.T) {
+	cases := map[string]struct {
+		create  bson.D
+		wantErr bool
+	}{
+		"created": {
+			create: mtest.CreateSuccessResponse(),
+		},
+		"already exists": {
+			create: mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 48, Name: "NamespaceExists", Message: "collection already exists"}),
+		},
+		"invalid collation": {
+			create:  mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 2, Name: "BadValue", Message: "unknown locale"}),
+			wantErr: true,
+		},
+		"unauthorized": {
+			create:  mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Name: "Unauthorized", Message: "not authorized"}),
+			wantErr: true,
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.$cmd.listCollections", mtest.FirstBatch), tc.create)
+			err := ensurePlaceholder(context.Background(), mt.Client.Database("db"), "_placeholder", nil)
+			if (err != nil) != tc.wantErr {
+				mt.Fatalf("error = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,661 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/datafy-io/terraform-provider-mongodb/internal/importid"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/retry"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// idIndexName is the name of the _id index every collection has; it's never managed.
+const idIndexName = "_id_"
+
+// indexNotFoundCode is the server error code returned when dropping a missing index.
+const indexNotFoundCode = 27
+
+var _ resource.Resource = &BatchResource{}
+var _ resource.ResourceWithConfigure = &BatchResource{}
+var _ resource.ResourceWithImportState = &BatchResource{}
+var _ resource.ResourceWithValidateConfig = &BatchResource{}
+
+// batchTimeouts are the operation time limits used without a timeouts block.
+var batchTimeouts = timeouts.Defaults{
+	Create: 30 * time.Minute,
+	Update: 30 * time.Minute,
+	Delete: 10 * time.Minute,
+}
+
+func NewBatchResource() resource.Resource { return &BatchResource{} }
+
+type BatchResource struct {
+	client *mongo.Client
+}
+
+type batchIndexModel struct {
+	Name      types.String         `tfsdk:"name"`
+	Unique    types.Bool           `tfsdk:"unique"`
+	Sparse    types.Bool           `tfsdk:"sparse"`
+	Hidden    types.Bool           `tfsdk:"hidden"`
+	TTL       types.Int32          `tfsdk:"ttl"`
+	Partial   jsontypes.Normalized `tfsdk:"partial_filter_expression"`
+	Collation jsontypes.Normalized `tfsdk:"collation"`
+	Keys      []indexKeyModel      `tfsdk:"keys"`
+}
+
+type BatchResourceModel struct {
+	ID         types.String      `tfsdk:"id"`
+	Database   types.String      `tfsdk:"database"`
+	Collection types.String      `tfsdk:"collection"`
+	Namespace  types.String      `tfsdk:"namespace"`
+	Indexes    []batchIndexModel `tfsdk:"index"`
+
+	Timeouts *timeouts.Model `tfsdk:"timeouts"`
+}
+
+func (r *BatchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_indexes"
+}
+
+func (r *BatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages all indexes of one MongoDB collection except _id_. New indexes are built together in one createIndexes command; on update, removed or changed indexes are dropped and the rest are created. Indexes created outside this resource show up as changes and are dropped on the next apply, so don't combine it with mongodb_index on the same collection.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "Database name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"collection": schema.StringAttribute{
+				Required:    true,
+				Description: "Collection name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Computed:    true,
+				Description: "Dotted namespace of the indexed collection, i.e. 'database.collection'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"index": schema.ListNestedBlock{
+				Description: "An index to manage. Indexes are matched by name; changing any other setting of an index drops and rebuilds it.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Index name.",
+						},
+						"unique": schema.BoolAttribute{
+							Optional:    true,
+							Description: "If true, the index enforces a uniqueness constraint on the indexed field(s).",
+						},
+						"sparse": schema.BoolAttribute{
+							Optional:    true,
+							Description: "If true, the index only includes documents that contain the indexed field.",
+						},
+						"hidden": schema.BoolAttribute{
+							Optional:    true,
+							Description: "If true, the index is hidden from the query planner but still maintained. Changed in place. Requires MongoDB 4.4+.",
+						},
+						"ttl": schema.Int32Attribute{
+							Optional:    true,
+							Description: "Time-to-live in seconds for a TTL index.",
+						},
+						"partial_filter_expression": schema.StringAttribute{
+							CustomType:  jsontypes.NormalizedType{},
+							Optional:    true,
+							Description: "JSON string for partial filter expression.",
+							Validators: []validator.String{
+								extJSONDocumentValidator{},
+							},
+						},
+						"collation": schema.StringAttribute{
+							CustomType:  jsontypes.NormalizedType{},
+							Optional:    true,
+							Description: "JSON string for the index collation, e.g. {\"locale\": \"fr\", \"strength\": 2}. Settings left out take the server defaults for the locale.",
+							Validators: []validator.String{
+								extJSONDocumentValidator{},
+							},
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"keys": schema.ListNestedBlock{
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"field": schema.StringAttribute{
+										Required: true,
+									},
+									"order": schema.Int64Attribute{
+										Optional:    true,
+										Description: "Sort order of the field: 1 for ascending, -1 for descending. Exactly one of order or type must be set.",
+										Validators: []validator.Int64{
+											int64validator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("type")),
+										},
+									},
+									"type": schema.StringAttribute{
+										Optional:    true,
+										Description: "Special index type of the field. One of 'text', '2dsphere', '2d', or 'geoHaystack'.",
+										Validators: []validator.String{
+											stringvalidator.OneOf(keyTypes...),
+										},
+									},
+								},
+							},
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"timeouts": timeouts.Block(batchTimeouts),
+		},
+	}
+}
+
+func (r *BatchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BatchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config BatchResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, idx := range config.Indexes {
+		if idx.Name.IsUnknown() || idx.Name.IsNull() {
+			continue
+		}
+		name := idx.Name.ValueString()
+		if name == idIndexName {
+			resp.Diagnostics.AddAttributeError(path.Root("index").AtListIndex(i).AtName("name"), "Reserved index name", "The _id index always exists and can't be managed.")
+		}
+		if seen[name] {
+			resp.Diagnostics.AddAttributeError(path.Root("index").AtListIndex(i).AtName("name"), "Duplicate index name", fmt.Sprintf("Index %s is declared more than once.", name))
+		}
+		seen[name] = true
+	}
+
+	for i, idx := range config.Indexes {
+		if c := idx.Collation.ValueString(); c != "" {
+			if _, err := parseCollation(c); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("index").AtListIndex(i).AtName("collation"), "Invalid collation", err.Error())
+			}
+		}
+	}
+}
+
+func (r *BatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.CreateContext(ctx, batchTimeouts)
+	defer cancel()
+
+	indexes := r.client.Database(plan.Database.ValueString()).Collection(plan.Collection.ValueString()).Indexes()
+
+	existing, err := ExIndexView{indexes}.ListExSpecifications(ctx)
+	if err != nil && !isNamespaceNotFound(err) {
+		resp.Diagnostics.AddError("List indexes failed", err.Error())
+		return
+	}
+	var taken []string
+	for _, idx := range plan.Indexes {
+		if existing.Find(idx.Name.ValueString()) != nil {
+			taken = append(taken, idx.Name.ValueString())
+		}
+	}
+	if len(taken) > 0 {
+		resp.Diagnostics.AddError(
+			"Index already exists",
+			fmt.Sprintf("Indexes named %s already exist. Import them or remove them from the configuration.", strings.Join(taken, ", ")),
+		)
+		return
+	}
+
+	if err := r.createIndexes(ctx, indexes, plan.Indexes); err != nil {
+		resp.Diagnostics.AddError("create indexes failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Collection.ValueString()))
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Collection.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	specs, err := ExIndexView{r.client.Database(state.Database.ValueString()).Collection(state.Collection.ValueString()).Indexes()}.ListExSpecifications(ctx)
+	if err != nil && !isNamespaceNotFound(err) {
+		resp.Diagnostics.AddError("Failed to list index specifications", err.Error())
+		return
+	}
+
+	// Every index but _id_ is managed, so ones created out of band are read in for the next plan to drop.
+	// A freshly imported resource declares nothing yet and takes them all.
+	prior := state.Indexes
+	for _, spec := range specs {
+		if spec != nil && spec.Name != idIndexName && !containsIndex(prior, spec.Name) {
+			prior = append(prior, batchIndexModel{Name: types.StringValue(spec.Name)})
+		}
+	}
+
+	// Indexes dropped out of band are left out so the next plan recreates them
+	state.Indexes = make([]batchIndexModel, 0, len(prior))
+	for _, p := range prior {
+		spec := specs.Find(p.Name.ValueString())
+		if spec == nil {
+			continue
+		}
+		idx, err := readBatchIndex(p, spec)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to decode index "+spec.Name, err.Error())
+			return
+		}
+		state.Indexes = append(state.Indexes, idx)
+	}
+	if len(state.Indexes) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s", state.Database.ValueString(), state.Collection.ValueString()))
+	state.Namespace = types.StringValue(fmt.Sprintf("%s.%s", state.Database.ValueString(), state.Collection.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state BatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.UpdateContext(ctx, batchTimeouts)
+	defer cancel()
+
+	current := map[string]batchIndexModel{}
+	for _, idx := range state.Indexes {
+		current[idx.Name.ValueString()] = idx
+	}
+	wanted := map[string]bool{}
+	var create, rehide []batchIndexModel
+	for _, idx := range plan.Indexes {
+		name := idx.Name.ValueString()
+		wanted[name] = true
+		if prior, ok := current[name]; ok && sameBatchIndex(ctx, prior, idx) {
+			if prior.Hidden.ValueBool() != idx.Hidden.ValueBool() {
+				rehide = append(rehide, idx)
+			}
+			continue
+		}
+		create = append(create, idx)
+	}
+
+	// Drop before creating so a changed index can be rebuilt under the same name
+	indexes := r.client.Database(plan.Database.ValueString()).Collection(plan.Collection.ValueString()).Indexes()
+	for _, idx := range state.Indexes {
+		name := idx.Name.ValueString()
+		if wanted[name] && !containsIndex(create, name) {
+			continue
+		}
+		if err := dropIndex(ctx, r.client, indexes, name); err != nil {
+			resp.Diagnostics.AddError("drop index failed", fmt.Sprintf("%s: %s", name, err))
+			return
+		}
+	}
+
+	if len(create) > 0 {
+		if err := r.createIndexes(ctx, indexes, create); err != nil {
+			resp.Diagnostics.AddError("create indexes failed", err.Error())
+			return
+		}
+	}
+
+	// Visibility changes in place, without a rebuild
+	for _, idx := range rehide {
+		cmd := bson.D{
+			{Key: "collMod", Value: plan.Collection.ValueString()},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: idx.Name.ValueString()},
+				{Key: "hidden", Value: idx.Hidden.ValueBool()},
+			}},
+		}
+		err := retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return r.client.Database(plan.Database.ValueString()).RunCommand(ctx, cmd).Err()
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to change index visibility", fmt.Sprintf("%s: %s", idx.Name.ValueString(), err))
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Collection.ValueString()))
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Collection.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state BatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.DeleteContext(ctx, batchTimeouts)
+	defer cancel()
+
+	indexes := r.client.Database(state.Database.ValueString()).Collection(state.Collection.ValueString()).Indexes()
+	for _, idx := range state.Indexes {
+		if err := dropIndex(ctx, r.client, indexes, idx.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddError("drop index failed", fmt.Sprintf("%s: %s", idx.Name.ValueString(), err))
+			return
+		}
+	}
+}
+
+func (r *BatchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		resp.Diagnostics.AddError(
+			"Empty import ID",
+			"Expected format: 'database/collection'",
+		)
+		return
+	}
+
+	parts, ok := importid.Split(id, 2)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected 'database/collection', with any '/' in a name encoded as %%2F, got %s", id),
+		)
+		return
+	}
+	db, coll := parts[0], parts[1]
+
+	var state BatchResourceModel
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s", db, coll))
+	state.Database = types.StringValue(db)
+	state.Collection = types.StringValue(coll)
+	state.Namespace = types.StringValue(fmt.Sprintf("%s.%s", db, coll))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// createIndexes builds all given indexes in a single createIndexes command.
+func (r *BatchResource) createIndexes(ctx context.Context, indexes mongo.IndexView, defs []batchIndexModel) error {
+	models := make([]mongo.IndexModel, 0, len(defs))
+	for _, def := range defs {
+		model, err := def.toIndexModel()
+		if err != nil {
+			return fmt.Errorf("index %s: %w", def.Name.ValueString(), err)
+		}
+		models = append(models, model)
+	}
+
+	return retry.Do(ctx, r.client, func(ctx context.Context) error {
+		_, err := indexes.CreateMany(ctx, models)
+		return err
+	})
+}
+
+func (m batchIndexModel) toIndexModel() (mongo.IndexModel, error) {
+	keys := bson.D{}
+	for _, k := range m.Keys {
+		if !k.Type.IsNull() {
+			keys = append(keys, bson.E{Key: k.Field.ValueString(), Value: k.Type.ValueString()})
+			continue
+		}
+		keys = append(keys, bson.E{Key: k.Field.ValueString(), Value: int(k.Order.ValueInt64())})
+	}
+
+	opts := options.Index().SetName(m.Name.ValueString())
+	opts.Unique = m.Unique.ValueBoolPointer()
+	opts.Sparse = m.Sparse.ValueBoolPointer()
+	if m.Hidden.ValueBool() {
+		opts.SetHidden(true)
+	}
+	opts.ExpireAfterSeconds = m.TTL.ValueInt32Pointer()
+
+	if p := m.Partial.ValueString(); p != "" {
+		var raw bson.Raw
+		if err := bson.UnmarshalExtJSON([]byte(p), false, &raw); err != nil {
+			return mongo.IndexModel{}, fmt.Errorf("invalid partial_filter_expression JSON: %w", err)
+		}
+		opts.SetPartialFilterExpression(raw)
+	}
+	if c := m.Collation.ValueString(); c != "" {
+		collation, err := parseCollation(c)
+		if err != nil {
+			return mongo.IndexModel{}, fmt.Errorf("invalid collation JSON: %w", err)
+		}
+		opts.SetCollation(collation)
+	}
+
+	return mongo.IndexModel{Keys: keys, Options: opts}, nil
+}
+
+// readBatchIndex reads spec into an index entry, keeping prior's unset defaults like the index resource does.
+func readBatchIndex(prior batchIndexModel, spec *ExIndexSpecification) (batchIndexModel, error) {
+	idx := batchIndexModel{
+		Name:      types.StringValue(spec.Name),
+		Unique:    prior.Unique,
+		Sparse:    prior.Sparse,
+		Hidden:    prior.Hidden,
+		TTL:       prior.TTL,
+		Partial:   jsontypes.NewNormalizedNull(),
+		Collation: jsontypes.NewNormalizedNull(),
+	}
+
+	// Only read non-defaults into state when attribute wasn't configured
+	if v := types.BoolValue(boolOrFalse(spec.Unique)); v.ValueBool() || !prior.Unique.IsNull() {
+		idx.Unique = v
+	}
+	if v := types.BoolValue(boolOrFalse(spec.Sparse)); v.ValueBool() || !prior.Sparse.IsNull() {
+		idx.Sparse = v
+	}
+	if v := types.BoolValue(boolOrFalse(spec.Hidden)); v.ValueBool() || !prior.Hidden.IsNull() {
+		idx.Hidden = v
+	}
+	if v := types.Int32PointerValue(spec.ExpireAfterSeconds); v.ValueInt32() != 0 || !prior.TTL.IsNull() {
+		idx.TTL = v
+	}
+
+	collation, err := readCollation(prior.Collation, spec.Collation)
+	if err != nil {
+		return idx, err
+	}
+	idx.Collation = collation
+
+	if len(spec.PartialFilterExpression) > 0 {
+		// Relaxed mode keeps plain numbers (e.g. 5 instead of {"$numberInt":"5"}) so configured JSON round-trips
+		extJSON, err := bson.MarshalExtJSON(spec.PartialFilterExpression, false, false)
+		if err != nil {
+			return idx, err
+		}
+		idx.Partial = jsontypes.NewNormalizedValue(string(extJSON))
+	}
+
+	keys, err := spec.decodeKeys()
+	if err != nil {
+		return idx, err
+	}
+	idx.Keys = alignTextKeys(prior.Keys, keys)
+	return idx, nil
+}
+
+// parseCollation decodes a collation JSON document. options.Collation has no bson tags for the server's
+// camelCase keys, so it is decoded through a tagged copy.
+func parseCollation(s string) (*options.Collation, error) {
+	var c struct {
+		Locale          string `bson:"locale"`
+		CaseLevel       bool   `bson:"caseLevel"`
+		CaseFirst       string `bson:"caseFirst"`
+		Strength        int    `bson:"strength"`
+		NumericOrdering bool   `bson:"numericOrdering"`
+		Alternate       string `bson:"alternate"`
+		MaxVariable     string `bson:"maxVariable"`
+		Normalization   bool   `bson:"normalization"`
+		Backwards       bool   `bson:"backwards"`
+	}
+	if err := bson.UnmarshalExtJSON([]byte(s), false, &c); err != nil {
+		return nil, err
+	}
+	if c.Locale == "" {
+		return nil, errors.New("locale is required")
+	}
+	return &options.Collation{
+		Locale:          c.Locale,
+		CaseLevel:       c.CaseLevel,
+		CaseFirst:       c.CaseFirst,
+		Strength:        c.Strength,
+		NumericOrdering: c.NumericOrdering,
+		Alternate:       c.Alternate,
+		MaxVariable:     c.MaxVariable,
+		Normalization:   c.Normalization,
+		Backwards:       c.Backwards,
+	}, nil
+}
+
+// readCollation returns the collation the server reports for an index. The server fills in every setting,
+// so a configured collation is kept as long as each setting it names matches; "version" is always left out.
+func readCollation(prior jsontypes.Normalized, raw bson.Raw) (jsontypes.Normalized, error) {
+	if len(raw) == 0 {
+		return jsontypes.NewNormalizedNull(), nil
+	}
+	var current bson.M
+	if err := bson.Unmarshal(raw, &current); err != nil {
+		return jsontypes.Normalized{}, err
+	}
+	delete(current, "version")
+
+	if p := prior.ValueString(); p != "" {
+		var configured bson.M
+		if err := bson.UnmarshalExtJSON([]byte(p), false, &configured); err == nil && collationMatches(configured, current) {
+			return prior, nil
+		}
+	}
+
+	extJSON, err := bson.MarshalExtJSON(current, false, false)
+	if err != nil {
+		return jsontypes.Normalized{}, err
+	}
+	return jsontypes.NewNormalizedValue(string(extJSON)), nil
+}
+
+// collationMatches reports whether every configured collation setting has the same value on the server.
+func collationMatches(configured, current bson.M) bool {
+	for k, v := range configured {
+		got, ok := current[k]
+		if !ok || fmt.Sprint(got) != fmt.Sprint(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameBatchIndex reports whether two entries with the same name define the same index. Visibility is
+// changed in place, so hidden is not compared.
+func sameBatchIndex(ctx context.Context, a, b batchIndexModel) bool {
+	if !a.Unique.Equal(b.Unique) || !a.Sparse.Equal(b.Sparse) || !a.TTL.Equal(b.TTL) || len(a.Keys) != len(b.Keys) {
+		return false
+	}
+	for i := range a.Keys {
+		if !a.Keys[i].Field.Equal(b.Keys[i].Field) || !a.Keys[i].Order.Equal(b.Keys[i].Order) || !a.Keys[i].Type.Equal(b.Keys[i].Type) {
+			return false
+		}
+	}
+
+	return sameJSON(ctx, a.Partial, b.Partial) && sameJSON(ctx, a.Collation, b.Collation)
+}
+
+func sameJSON(ctx context.Context, a, b jsontypes.Normalized) bool {
+	if a.IsNull() || b.IsNull() {
+		return a.IsNull() == b.IsNull()
+	}
+	equal, diags := a.StringSemanticEquals(ctx, b)
+	return equal && !diags.HasError()
+}
+
+func containsIndex(defs []batchIndexModel, name string) bool {
+	for _, def := range defs {
+		if def.Name.ValueString() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// dropIndex drops the named index, treating an index or collection that is already gone as dropped.
+func dropIndex(ctx context.Context, client *mongo.Client, indexes mongo.IndexView, name string) error {
+	err := retry.Do(ctx, client, func(ctx context.Context) error {
+		_, err := indexes.DropOne(ctx, name)
+		return err
+	})
+	var cmdErr mongo.CommandError
+	if isNamespaceNotFound(err) || (errors.As(err, &cmdErr) && (cmdErr.Code == indexNotFoundCode || cmdErr.Name == "IndexNotFound")) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,268 @@
+package index
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// batchIndex is a declared ascending single-field index named after its field.
+func batchIndex(field string) batchIndexModel {
+	return batchIndexModel{
+		Name: types.StringValue(field + "_1"),
+		Keys: []indexKeyModel{{Field: types.StringValue(field), Order: types.Int64Value(1), Type: types.StringNull()}},
+	}
+}
+
+func batchModel(indexes ...batchIndexModel) BatchResourceModel {
+	return BatchResourceModel{
+		ID:         types.StringValue("db/coll"),
+		Database:   types.StringValue("db"),
+		Collection: types.StringValue("coll"),
+		Namespace:  types.StringValue("db.coll"),
+		Indexes:    indexes,
+	}
+}
+
+// ascendingSpec is the listIndexes entry for batchIndex(field), with any extra options appended.
+func ascendingSpec(field string, extra ...bson.E) bson.D {
+	return indexSpec(field+"_1", bson.D{{Key: field, Value: int32(1)}}, extra...)
+}
+
+var idIndexSpec = indexSpec(idIndexName, bson.D{{Key: "_id", Value: int32(1)}})
+
+func readBatch(t *testing.T, mt *mtest.T, prior BatchResourceModel) *BatchResourceModel {
+	t.Helper()
+	r := &BatchResource{client: mt.Client}
+	state := newState(t, r, &prior)
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read: %v", resp.Diagnostics)
+	}
+	if resp.State.Raw.IsNull() {
+		return nil
+	}
+	var model BatchResourceModel
+	getState(t, resp.State, &model)
+	return &model
+}
+
+func TestBatchCreate(t *testing.T) {
+	mt := newMockTest(t)
+	mt.Run("create", func(mt *mtest.T) {
+		mt.AddMockResponses(listIndexesResponse(idIndexSpec), mtest.CreateSuccessResponse())
+
+		r := &BatchResource{client: mt.Client}
+		plan := batchModel(batchIndex("a"), batchIndex("b"))
+		planState := newState(mt.T, r, &plan)
+		resp := resource.CreateResponse{State: newState(mt.T, r, nil)}
+		r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, &resp)
+		if resp.Diagnostics.HasError() {
+			mt.Fatalf("create: %v", resp.Diagnostics)
+		}
+
+		if got, want := commandNames(mt), []string{"listIndexes", "createIndexes"}; !slices.Equal(got, want) {
+			mt.Fatalf("commands = %v, want %v", got, want)
+		}
+		var cmd struct {
+			Indexes []struct {
+				Name string `bson:"name"`
+			} `bson:"indexes"`
+		}
+		if err := bson.Unmarshal(mt.GetAllStartedEvents()[1].Command, &cmd); err != nil {
+			mt.Fatal(err)
+		}
+		if len(cmd.Indexes) != 2 || cmd.Indexes[0].Name != "a_1" || cmd.Indexes[1].Name != "b_1" {
+			mt.Errorf("createIndexes built %+v, want a_1 and b_1 in one command", cmd.Indexes)
+		}
+	})
+}
+
+func TestBatchUpdate(t *testing.T) {
+	hiddenA := batchIndex("a")
+	hiddenA.Hidden = types.BoolValue(true)
+
+	cases := map[string]struct {
+		state    BatchResourceModel
+		plan     BatchResourceModel
+		commands []string
+	}{
+		"add and drop": {
+			state:    batchModel(batchIndex("a"), batchIndex("b")),
+			plan:     batchModel(batchIndex("a"), batchIndex("c")),
+			commands: []string{"dropIndexes", "createIndexes"},
+		},
+		"hide in place": {
+			state:    batchModel(batchIndex("a")),
+			plan:     batchModel(hiddenA),
+			commands: []string{"collMod"},
+		},
+		"reordered": {
+			state: batchModel(batchIndex("a"), batchIndex("b")),
+			plan:  batchModel(batchIndex("b"), batchIndex("a")),
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			for range tc.commands {
+				mt.AddMockResponses(mtest.CreateSuccessResponse())
+			}
+			r := &BatchResource{client: mt.Client}
+			planState := newState(mt.T, r, &tc.plan)
+			req := resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw},
+				State: newState(mt.T, r, &tc.state),
+			}
+			resp := resource.UpdateResponse{State: req.State}
+			r.Update(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() {
+				mt.Fatalf("update: %v", resp.Diagnostics)
+			}
+			if got := commandNames(mt); !slices.Equal(got, tc.commands) {
+				mt.Errorf("commands = %v, want %v", got, tc.commands)
+			}
+		})
+	}
+}
+
+// TestBatchReadManagesAllIndexes checks that Read picks up an index created out of band, so the next plan
+// converges to the declared list by dropping it.
+func TestBatchReadManagesAllIndexes(t *testing.T) {
+	mt := newMockTest(t)
+	mt.Run("index created out of band", func(mt *mtest.T) {
+		mt.AddMockResponses(listIndexesResponse(idIndexSpec, ascendingSpec("a"), ascendingSpec("x")))
+		state := readBatch(mt.T, mt, batchModel(batchIndex("a")))
+		if state == nil {
+			mt.Fatal("resource was removed from state")
+		}
+		var names []string
+		for _, idx := range state.Indexes {
+			names = append(names, idx.Name.ValueString())
+		}
+		if want := []string{"a_1", "x_1"}; !slices.Equal(names, want) {
+			mt.Errorf("indexes = %v, want %v", names, want)
+		}
+	})
+
+	mt.Run("index dropped out of band", func(mt *mtest.T) {
+		mt.AddMockResponses(listIndexesResponse(idIndexSpec, ascendingSpec("a")))
+		state := readBatch(mt.T, mt, batchModel(batchIndex("a"), batchIndex("b")))
+		if state == nil || len(state.Indexes) != 1 || state.Indexes[0].Name.ValueString() != "a_1" {
+			mt.Errorf("state = %+v, want only a_1", state)
+		}
+	})
+}
+
+func TestBatchImport(t *testing.T) {
+	frenchCollation := bson.D{
+		{Key: "locale", Value: "fr"},
+		{Key: "caseLevel", Value: false},
+		{Key: "caseFirst", Value: "off"},
+		{Key: "strength", Value: int32(2)},
+		{Key: "numericOrdering", Value: false},
+		{Key: "alternate", Value: "non-ignorable"},
+		{Key: "maxVariable", Value: "punct"},
+		{Key: "normalization", Value: false},
+		{Key: "backwards", Value: false},
+		{Key: "version", Value: "57.1"},
+	}
+
+	mt := newMockTest(t)
+	mt.Run("hidden and collation", func(mt *mtest.T) {
+		mt.AddMockResponses(listIndexesResponse(
+			idIndexSpec,
+			ascendingSpec("a", bson.E{Key: "hidden", Value: true}, bson.E{Key: "collation", Value: frenchCollation}),
+			ascendingSpec("b"),
+		))
+
+		r := &BatchResource{client: mt.Client}
+		importResp := resource.ImportStateResponse{State: newState(mt.T, r, nil)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: "db/coll"}, &importResp)
+		if importResp.Diagnostics.HasError() {
+			mt.Fatalf("import: %v", importResp.Diagnostics)
+		}
+		var imported BatchResourceModel
+		getState(mt.T, importResp.State, &imported)
+
+		state := readBatch(mt.T, mt, imported)
+		if state == nil || len(state.Indexes) != 2 {
+			mt.Fatalf("state = %+v, want a_1 and b_1", state)
+		}
+		a, b := state.Indexes[0], state.Indexes[1]
+		if !a.Hidden.Equal(types.BoolValue(true)) || !b.Hidden.IsNull() {
+			mt.Errorf("hidden = %s, %s, want true, null", a.Hidden, b.Hidden)
+		}
+		if a.Collation.IsNull() || !b.Collation.IsNull() {
+			mt.Fatalf("collation = %s, %s, want a_1's only", a.Collation, b.Collation)
+		}
+
+		// The imported collation must build the same index again
+		model, err := a.toIndexModel()
+		if err != nil {
+			mt.Fatal(err)
+		}
+		if c := model.Options.Collation; c == nil || c.Locale != "fr" || c.Strength != 2 || c.Alternate != "non-ignorable" {
+			mt.Errorf("collation options = %+v, want the imported French collation", c)
+		}
+	})
+}
+
+func TestReadCollation(t *testing.T) {
+	server, err := bson.Marshal(bson.D{
+		{Key: "locale", Value: "fr"},
+		{Key: "strength", Value: int32(2)},
+		{Key: "caseLevel", Value: false},
+		{Key: "version", Value: "57.1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		prior jsontypes.Normalized
+		raw   bson.Raw
+		want  jsontypes.Normalized
+	}{
+		"no collation": {
+			prior: jsontypes.NewNormalizedNull(),
+			want:  jsontypes.NewNormalizedNull(),
+		},
+		"configured subset": {
+			prior: jsontypes.NewNormalizedValue(`{"locale": "fr", "strength": 2}`),
+			raw:   server,
+			want:  jsontypes.NewNormalizedValue(`{"locale": "fr", "strength": 2}`),
+		},
+		"changed out of band": {
+			prior: jsontypes.NewNormalizedValue(`{"locale": "fr", "strength": 3}`),
+			raw:   server,
+			want:  jsontypes.NewNormalizedValue(`{"caseLevel":false,"locale":"fr","strength":2}`),
+		},
+		"imported": {
+			prior: jsontypes.NewNormalizedNull(),
+			raw:   server,
+			want:  jsontypes.NewNormalizedValue(`{"caseLevel":false,"locale":"fr","strength":2}`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := readCollation(tc.prior, tc.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameJSON(context.Background(), got, tc.want) {
+				t.Errorf("collation = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	indexOptionsConflictCode  = 85
+	indexKeySpecsConflictCode = 86
+)
+
+func isIndexConflict(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == indexOptionsConflictCode || cmdErr.Code == indexKeySpecsConflictCode
+	}
+	return false
+}
+
+// conflictDetail explains an IndexOptionsConflict/IndexKeySpecsConflict by comparing the
+// requested index with the existing one it collides with.
+func conflictDetail(ctx context.Context, indexes mongo.IndexView, keys bson.D, opts *options.IndexOptions, cause error) string {
+	specs, err := ExIndexView{indexes}.ListExSpecifications(ctx)
+	if err != nil {
+		return cause.Error()
+	}
+
+	requestedKeys, err := bson.Marshal(keys)
+	if err != nil {
+		return cause.Error()
+	}
+
+	// Same name with different keys, or same keys under another name
+	existing := specs.Find(*opts.Name)
+	if existing == nil {
+		for _, spec := range specs {
+			if spec != nil && bytes.Equal(spec.KeysDocument, requestedKeys) {
+				existing = spec
+				break
+			}
+		}
+	}
+	if existing == nil {
+		return cause.Error()
+	}
+
+	var diffs []string
+	if existing.Name != *opts.Name {
+		diffs = append(diffs, fmt.Sprintf("name=%q (requested %q)", existing.Name, *opts.Name))
+	}
+	if !bytes.Equal(existing.KeysDocument, requestedKeys) {
+		diffs = append(diffs, fmt.Sprintf("keys=%s (requested %s)", existing.KeysDocument, bson.Raw(requestedKeys)))
+	}
+	if b, r := existing.Unique != nil && *existing.Unique, opts.Unique != nil && *opts.Unique; b != r {
+		diffs = append(diffs, fmt.Sprintf("unique=%t (requested %t)", b, r))
+	}
+	if b, r := existing.Sparse != nil && *existing.Sparse, opts.Sparse != nil && *opts.Sparse; b != r {
+		diffs = append(diffs, fmt.Sprintf("sparse=%t (requested %t)", b, r))
+	}
+	if e, r := int32OrZero(existing.ExpireAfterSeconds), int32OrZero(opts.ExpireAfterSeconds); e != r {
+		diffs = append(diffs, fmt.Sprintf("ttl=%d (requested %d)", e, r))
+	}
+
+	return fmt.Sprintf(
+		"%s\n\nIndex %q already exists with %s. Import the existing index or drop and recreate it.",
+		cause, existing.Name, strings.Join(diffs, ", "),
+	)
+}
+
+func int32OrZero(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,31 @@
+package index
+
+const (
+	sphereKeyType   = "2dsphere"
+	flatKeyType     = "2d"
+	haystackKeyType = "geoHaystack"
+
+	// Server defaults for the geospatial index options.
+	defaultSphereIndexVersion = 3
+	defaultBits               = 26
+	defaultMin                = -180.0
+	defaultMax                = 180.0
+)
+
+// keyTypes are the special key types accepted in keys.type.
+var keyTypes = []string{textKeyType, sphereKeyType, flatKeyType, haystackKeyType}
+
+// keyTypeState reports whether keys contain keyType, and whether that is known yet.
+func keyTypeState(keys []indexKeyModel, keyType string) (found, known bool) {
+	known = true
+	for _, k := range keys {
+		if k.Type.IsUnknown() {
+			known = false
+			continue
+		}
+		if k.Type.ValueString() == keyType {
+			return true, true
+		}
+	}
+	return false, known
+}
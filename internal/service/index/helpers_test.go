@@ -0,0 +1,115 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func newMockTest(t *testing.T) *mtest.T {
+	return mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+}
+
+// listIndexesResponse is a mocked listIndexes reply holding the given index specifications.
+func listIndexesResponse(specs ...bson.D) bson.D {
+	return mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch, specs...)
+}
+
+// indexSpec is a listIndexes entry for an index named name over keys, with any extra options appended.
+func indexSpec(name string, keys bson.D, extra ...bson.E) bson.D {
+	return append(bson.D{
+		{Key: "v", Value: int32(2)},
+		{Key: "key", Value: keys},
+		{Key: "name", Value: name},
+	}, extra...)
+}
+
+// newState returns state of the resource r holding model, or a null state when model is nil.
+func newState(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("schema: %v", schemaResp.Diagnostics)
+	}
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if model != nil {
+		if diags := state.Set(ctx, model); diags.HasError() {
+			t.Fatalf("set state: %v", diags)
+		}
+	}
+	return state
+}
+
+// getState decodes state into target, failing the test on error.
+func getState(t *testing.T, state tfsdk.State, target any) {
+	t.Helper()
+	if diags := state.Get(context.Background(), target); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+}
+
+// readIndex runs the index resource Read against client with prior as the stored state. It returns the new
+// state, or nil when the resource was removed.
+func readIndex(t *testing.T, client *mongo.Client, prior ResourceModel) *ResourceModel {
+	t.Helper()
+	r := &Resource{client: client}
+	state := newState(t, r, &prior)
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read: %v", resp.Diagnostics)
+	}
+	if resp.State.Raw.IsNull() {
+		return nil
+	}
+
+	var model ResourceModel
+	getState(t, resp.State, &model)
+	return &model
+}
+
+// importIndex runs ImportState for id followed by the Read Terraform performs after an import.
+func importIndex(t *testing.T, client *mongo.Client, id string) *ResourceModel {
+	t.Helper()
+	r := &Resource{client: client}
+	resp := resource.ImportStateResponse{State: newState(t, r, nil)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("import: %v", resp.Diagnostics)
+	}
+
+	var imported ResourceModel
+	getState(t, resp.State, &imported)
+	return readIndex(t, client, imported)
+}
+
+// commandNames returns the names of the commands started against the mocked server, in order.
+func commandNames(mt *mtest.T) []string {
+	var names []string
+	for _, e := range mt.GetAllStartedEvents() {
+		names = append(names, e.CommandName)
+	}
+	return names
+}
+
+// startedCommand returns the first started command with the given name, failing the test if there is none.
+func startedCommand(mt *mtest.T, name string) bson.Raw {
+	mt.Helper()
+	for _, e := range mt.GetAllStartedEvents() {
+		if e.CommandName == name {
+			return e.Command
+		}
+	}
+	mt.Fatalf("no %s in %v", name, commandNames(mt))
+	return nil
+}
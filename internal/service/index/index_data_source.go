@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -29,11 +30,20 @@ type DataSourceModel struct {
 	Database   types.String         `tfsdk:"database"`
 	Collection types.String         `tfsdk:"collection"`
 	Name       types.String         `tfsdk:"name"`
+	Namespace  types.String         `tfsdk:"namespace"`
 	Unique     types.Bool           `tfsdk:"unique"`
 	Sparse     types.Bool           `tfsdk:"sparse"`
+	Hidden     types.Bool           `tfsdk:"hidden"`
 	TTL        types.Int32          `tfsdk:"ttl"`
 	Partial    jsontypes.Normalized `tfsdk:"partial_filter_expression"`
 	Keys       []indexKeyModel      `tfsdk:"keys"`
+	KeyPrefix  []indexKeyModel      `tfsdk:"key_prefix"`
+
+	Weights          types.Map    `tfsdk:"weights"`
+	DefaultLanguage  types.String `tfsdk:"default_language"`
+	LanguageOverride types.String `tfsdk:"language_override"`
+
+	WildcardProjection jsontypes.Normalized `tfsdk:"wildcard_projection"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -56,8 +66,13 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Description: "Collection name.",
 			},
 			"name": schema.StringAttribute{
-				Required:    true,
-				Description: "Index name. If not specified, MongoDB will generate a name based on the indexed fields.",
+				Optional:    true,
+				Computed:    true,
+				Description: "Index name. Exactly one of name or key_prefix must be set.",
+			},
+			"namespace": schema.StringAttribute{
+				Computed:    true,
+				Description: "Dotted namespace of the indexed collection, i.e. 'database.collection'.",
 			},
 			"unique": schema.BoolAttribute{
 				Computed:    true,
@@ -67,6 +82,10 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Computed:    true,
 				Description: "If true, the index only includes documents that have the indexed field(s).",
 			},
+			"hidden": schema.BoolAttribute{
+				Computed:    true,
+				Description: "If true, the index is hidden from the query planner.",
+			},
 			"ttl": schema.Int32Attribute{
 				Computed:    true,
 				Description: "Time-to-live in seconds for the index. When specified, MongoDB will automatically delete documents when their indexed field value is older than the specified TTL.",
@@ -76,6 +95,24 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Computed:    true,
 				Description: "JSON string for partial filter expression.",
 			},
+			"wildcard_projection": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Computed:    true,
+				Description: "JSON string of the wildcard index projection.",
+			},
+			"weights": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Text index only. Weight of each text field.",
+			},
+			"default_language": schema.StringAttribute{
+				Computed:    true,
+				Description: "Text index only. Default language for stop words and stemming.",
+			},
+			"language_override": schema.StringAttribute{
+				Computed:    true,
+				Description: "Text index only. Document field that overrides the language per document.",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"keys": schema.ListNestedBlock{
@@ -87,6 +124,24 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 						"order": schema.Int64Attribute{
 							Computed: true,
 						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+					}},
+			},
+			"key_prefix": schema.ListNestedBlock{
+				Description: "Leading index keys to match, in order. The first index whose keys start with this prefix is returned. An entry without order or type matches the field whatever its order or type. Exactly one of name or key_prefix must be set.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Required: true,
+						},
+						"order": schema.Int64Attribute{
+							Optional: true,
+						},
+						"type": schema.StringAttribute{
+							Optional: true,
+						},
 					}},
 			},
 		},
@@ -124,54 +179,78 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
-	index := indexes.Find(plan.Name.ValueString())
+	hasName, hasPrefix := !plan.Name.IsNull(), len(plan.KeyPrefix) > 0
+	if hasName == hasPrefix {
+		resp.Diagnostics.AddError("Invalid index lookup", "Exactly one of name or key_prefix must be set.")
+		return
+	}
+
+	var index *ExIndexSpecification
+	if hasName {
+		index = indexes.Find(plan.Name.ValueString())
+	} else {
+		index, err = indexes.FindByKeyPrefix(plan.KeyPrefix)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to decode index keys", err.Error())
+			return
+		}
+	}
 	if index == nil {
 		resp.Diagnostics.AddError("Index not found", "")
 		return
 	}
+	plan.Name = types.StringValue(index.Name)
 
-	plan.Sparse = types.BoolPointerValue(index.Sparse)
-	plan.Unique = types.BoolPointerValue(index.Unique)
+	plan.Sparse = types.BoolValue(boolOrFalse(index.Sparse))
+	plan.Unique = types.BoolValue(boolOrFalse(index.Unique))
+	plan.Hidden = types.BoolValue(boolOrFalse(index.Hidden))
 	plan.TTL = types.Int32PointerValue(index.ExpireAfterSeconds)
 	if len(index.PartialFilterExpression) > 0 {
-		extJSON, err := bson.MarshalExtJSON(index.PartialFilterExpression, true, true)
+		// Relaxed mode keeps plain numbers (e.g. 5 instead of {"$numberInt":"5"}) so configured JSON round-trips
+		extJSON, err := bson.MarshalExtJSON(index.PartialFilterExpression, false, false)
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to marshal partial filter expression", err.Error())
 			return
 		}
 		plan.Partial = jsontypes.NewNormalizedValue(string(extJSON))
 	}
+	if len(index.WildcardProjection) > 0 {
+		extJSON, err := bson.MarshalExtJSON(index.WildcardProjection, false, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to marshal wildcard projection", err.Error())
+			return
+		}
+		plan.WildcardProjection = jsontypes.NewNormalizedValue(string(extJSON))
+	}
 
-	var keysDoc bson.D
-	if err := bson.Unmarshal(index.KeysDocument, &keysDoc); err != nil {
-		resp.Diagnostics.AddError("Failed to decode index keys", err.Error())
+	plan.DefaultLanguage = types.StringPointerValue(index.DefaultLanguage)
+	plan.LanguageOverride = types.StringPointerValue(index.LanguageOverride)
+	weights, err := index.decodeWeights()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to decode text index weights", err.Error())
 		return
 	}
-	plan.Keys = make([]indexKeyModel, 0, len(keysDoc))
-	for _, e := range keysDoc {
-		var order int64
-		switch v := e.Value.(type) {
-		case int32:
-			order = int64(v)
-		case int64:
-			order = v
-		case float64:
-			// allow 1.0 / -1.0 coming back as doubles
-			order = int64(v)
-		default:
-			// unsupported (e.g., "2dsphere", "text")
-			resp.Diagnostics.AddWarning(
-				"Non-numeric index key order encountered",
-				fmt.Sprintf("Field %q has unsupported type %T (value %v). Skipping.", e.Key, v, v),
-			)
-			continue
+	if len(weights) == 0 {
+		plan.Weights = types.MapNull(types.Int64Type)
+	} else {
+		values := map[string]int64{}
+		for _, w := range weights {
+			if weight, ok := numericOrder(w.Value); ok {
+				values[w.Key] = weight
+			}
 		}
-		plan.Keys = append(plan.Keys, indexKeyModel{
-			Field: types.StringValue(e.Key),
-			Order: types.Int64Value(order),
-		})
+		var diags diag.Diagnostics
+		plan.Weights, diags = types.MapValueFrom(ctx, types.Int64Type, values)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	plan.Keys, err = index.decodeKeys()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to decode index keys", err.Error())
+		return
 	}
 
 	plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", plan.Database.ValueString(), plan.Collection.ValueString(), plan.Name.ValueString()))
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Collection.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
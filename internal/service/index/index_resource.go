@@ -3,18 +3,31 @@ package index
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/datafy-io/terraform-provider-mongodb/internal/importid"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/retry"
+	"github.com/datafy-io/terraform-provider-mongodb/internal/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -27,6 +40,14 @@ import (
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithConfigure = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
+
+// resourceTimeouts are the operation time limits used without a timeouts block.
+var resourceTimeouts = timeouts.Defaults{
+	Create: 30 * time.Minute,
+	Update: 10 * time.Minute,
+	Delete: 10 * time.Minute,
+}
 
 func NewResource() resource.Resource { return &Resource{} }
 
@@ -37,6 +58,7 @@ type Resource struct {
 type indexKeyModel struct {
 	Field types.String `tfsdk:"field"`
 	Order types.Int64  `tfsdk:"order"`
+	Type  types.String `tfsdk:"type"`
 }
 
 type ResourceModel struct {
@@ -44,12 +66,31 @@ type ResourceModel struct {
 	Database       types.String         `tfsdk:"database"`
 	Collection     types.String         `tfsdk:"collection"`
 	Name           types.String         `tfsdk:"name"`
+	Namespace      types.String         `tfsdk:"namespace"`
 	Unique         types.Bool           `tfsdk:"unique"`
 	Sparse         types.Bool           `tfsdk:"sparse"`
+	Hidden         types.Bool           `tfsdk:"hidden"`
 	TTL            types.Int32          `tfsdk:"ttl"`
 	Partial        jsontypes.Normalized `tfsdk:"partial_filter_expression"`
 	Keys           []indexKeyModel      `tfsdk:"keys"`
 	PreventDestroy types.Bool           `tfsdk:"prevent_destroy"`
+	ForceDestroy   types.Bool           `tfsdk:"force_destroy"`
+	UsageWindow    types.Int64          `tfsdk:"usage_window_seconds"`
+	MaxTimeMS      types.Int64          `tfsdk:"max_time_ms"`
+
+	Weights          types.Map    `tfsdk:"weights"`
+	DefaultLanguage  types.String `tfsdk:"default_language"`
+	LanguageOverride types.String `tfsdk:"language_override"`
+
+	SphereIndexVersion types.Int64   `tfsdk:"sphere_index_version"`
+	Bits               types.Int64   `tfsdk:"bits"`
+	Min                types.Float64 `tfsdk:"min"`
+	Max                types.Float64 `tfsdk:"max"`
+	BucketSize         types.Int64   `tfsdk:"bucket_size"`
+
+	WildcardProjection jsontypes.Normalized `tfsdk:"wildcard_projection"`
+
+	Timeouts *timeouts.Model `tfsdk:"timeouts"`
 }
 
 func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -87,6 +128,13 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"namespace": schema.StringAttribute{
+				Computed:    true,
+				Description: "Dotted namespace of the indexed collection, i.e. 'database.collection'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"unique": schema.BoolAttribute{
 				Optional:    true,
 				Description: "If true, the index enforces a uniqueness constraint on the indexed field(s).",
@@ -101,17 +149,37 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 					boolplanmodifier.RequiresReplaceIfConfigured(),
 				},
 			},
+			"hidden": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the index is hidden from the query planner but still maintained, so it can be unhidden without a rebuild. Changed in place. Requires MongoDB 4.4+.",
+			},
 			"ttl": schema.Int32Attribute{
 				Optional:    true,
-				Description: "Time-to-live in seconds for the index. When specified, MongoDB will automatically delete documents when their indexed field value is older than the specified TTL.",
+				Description: "Time-to-live in seconds for the index. When specified, MongoDB will automatically delete documents when their indexed field value is older than the specified TTL. Changing the TTL of a TTL index is done in place; adding or removing it recreates the index.",
 				PlanModifiers: []planmodifier.Int32{
-					int32planmodifier.RequiresReplaceIfConfigured(),
+					int32planmodifier.RequiresReplaceIf(ttlRequiresReplace,
+						"Adding or removing the TTL recreates the index.",
+						"Adding or removing the TTL recreates the index."),
 				},
 			},
 			"partial_filter_expression": schema.StringAttribute{
 				CustomType:  jsontypes.NormalizedType{},
 				Optional:    true,
 				Description: "JSON string for partial filter expression.",
+				Validators: []validator.String{
+					extJSONDocumentValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"wildcard_projection": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Optional:    true,
+				Description: "JSON string of the fields to include or exclude from a wildcard index. Requires a '$**' key.",
+				Validators: []validator.String{
+					extJSONDocumentValidator{},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplaceIfConfigured(),
 				},
@@ -122,6 +190,97 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Default:     booldefault.StaticBool(false),
 				Description: "If true, prevents the index from being destroyed. (Default: false)",
 			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "If false, dropping the index, including to replace it, fails when $indexStats reports accesses within the last usage_window_seconds, guarding against dropping an index that live queries use. (Default: true)",
+			},
+			"usage_window_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultUsageWindowSeconds),
+				Description: fmt.Sprintf("How far back the force_destroy guard looks for accesses. Unless the usage counters started within the window, the drop waits this long to measure it, which must fit in the delete timeout. (Default: %d)", defaultUsageWindowSeconds),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_time_ms": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Server-side time limit in milliseconds for building the index. The build is aborted if it takes longer. Only used when the index is created.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"weights": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Description: "Text index only. Relative weight of each text field; fields not listed have weight 1.",
+				Validators: []validator.Map{
+					mapvalidator.ValueInt64sAre(int64validator.Between(1, 99999)),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"default_language": schema.StringAttribute{
+				Optional:    true,
+				Description: "Text index only. Language that determines stop words and stemming rules. (Default: english)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"language_override": schema.StringAttribute{
+				Optional:    true,
+				Description: "Text index only. Name of the document field that overrides the default language per document. (Default: language)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sphere_index_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "2dsphere index only. Index version (2dsphereIndexVersion). (Default: the server's latest, 3)",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 3),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"bits": schema.Int64Attribute{
+				Optional:    true,
+				Description: "2d index only. Precision of the stored geohash in bits. (Default: 26)",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 32),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"min": schema.Float64Attribute{
+				Optional:    true,
+				Description: "2d index only. Lower inclusive bound for longitude and latitude values. (Default: -180)",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"max": schema.Float64Attribute{
+				Optional:    true,
+				Description: "2d index only. Upper inclusive bound for longitude and latitude values. (Default: 180)",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"bucket_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: "geoHaystack index only, and required for it. Distance within which location values are grouped.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"keys": schema.ListNestedBlock{
@@ -134,11 +293,25 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 							},
 						},
 						"order": schema.Int64Attribute{
-							Required: true,
+							Optional:    true,
+							Description: "Sort order of the field: 1 for ascending, -1 for descending. Exactly one of order or type must be set.",
+							Validators: []validator.Int64{
+								int64validator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("type")),
+							},
 							PlanModifiers: []planmodifier.Int64{
 								int64planmodifier.RequiresReplace(),
 							},
 						},
+						"type": schema.StringAttribute{
+							Optional:    true,
+							Description: "Special index type of the field. One of 'text', '2dsphere', '2d', or 'geoHaystack'.",
+							Validators: []validator.String{
+								stringvalidator.OneOf(keyTypes...),
+							},
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.RequiresReplace(),
+							},
+						},
 					}},
 				Validators: []validator.List{
 					listvalidator.SizeAtLeast(1),
@@ -147,10 +320,16 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 					listplanmodifier.RequiresReplace(),
 				},
 			},
+			"timeouts": timeouts.Block(resourceTimeouts),
 		},
 	}
 }
 
+// ttlRequiresReplace allows changing an existing TTL through collMod, but adding or removing one needs a rebuild.
+func ttlRequiresReplace(_ context.Context, req planmodifier.Int32Request, resp *int32planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+}
+
 func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -169,6 +348,53 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 	r.client = client
 }
 
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, o := range []struct {
+		attr    string
+		set     bool
+		keyType string
+	}{
+		{"weights", !config.Weights.IsNull(), textKeyType},
+		{"default_language", !config.DefaultLanguage.IsNull(), textKeyType},
+		{"language_override", !config.LanguageOverride.IsNull(), textKeyType},
+		{"sphere_index_version", !config.SphereIndexVersion.IsNull(), sphereKeyType},
+		{"bits", !config.Bits.IsNull(), flatKeyType},
+		{"min", !config.Min.IsNull(), flatKeyType},
+		{"max", !config.Max.IsNull(), flatKeyType},
+		{"bucket_size", !config.BucketSize.IsNull(), haystackKeyType},
+	} {
+		if found, known := keyTypeState(config.Keys, o.keyType); o.set && known && !found {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(o.attr),
+				"Option without matching key type",
+				fmt.Sprintf("%s only applies to indexes with a %q key.", o.attr, o.keyType),
+			)
+		}
+	}
+
+	if !config.WildcardProjection.IsNull() && !config.WildcardProjection.IsUnknown() && !hasWildcardKey(config.Keys) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wildcard_projection"),
+			"wildcard_projection without wildcard key",
+			fmt.Sprintf("wildcard_projection only applies to indexes with a %q key.", wildcardField),
+		)
+	}
+
+	if found, _ := keyTypeState(config.Keys, haystackKeyType); found && config.BucketSize.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("bucket_size"),
+			"Missing bucket_size",
+			"bucket_size is required for geoHaystack indexes.",
+		)
+	}
+}
+
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -176,6 +402,9 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	ctx, cancel := plan.Timeouts.CreateContext(ctx, resourceTimeouts)
+	defer cancel()
+
 	indexes := r.client.Database(plan.Database.ValueString()).Collection(plan.Collection.ValueString()).Indexes()
 
 	specifications, err := indexes.ListSpecifications(ctx)
@@ -196,6 +425,10 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	keys := bson.D{}
 	for _, k := range plan.Keys {
+		if !k.Type.IsNull() {
+			keys = append(keys, bson.E{Key: k.Field.ValueString(), Value: k.Type.ValueString()})
+			continue
+		}
 		keys = append(keys, bson.E{Key: k.Field.ValueString(), Value: int(k.Order.ValueInt64())})
 	}
 
@@ -206,25 +439,85 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	idx.Options.Unique = plan.Unique.ValueBoolPointer()
 	idx.Options.Sparse = plan.Sparse.ValueBoolPointer()
+	if plan.Hidden.ValueBool() {
+		idx.Options.SetHidden(true)
+	}
 	idx.Options.ExpireAfterSeconds = plan.TTL.ValueInt32Pointer()
 	idx.Options.Name = plan.Name.ValueStringPointer()
+	idx.Options.DefaultLanguage = plan.DefaultLanguage.ValueStringPointer()
+	idx.Options.LanguageOverride = plan.LanguageOverride.ValueStringPointer()
+	if !plan.SphereIndexVersion.IsNull() {
+		idx.Options.SetSphereVersion(int32(plan.SphereIndexVersion.ValueInt64()))
+	}
+	if !plan.Bits.IsNull() {
+		idx.Options.SetBits(int32(plan.Bits.ValueInt64()))
+	}
+	idx.Options.Min = plan.Min.ValueFloat64Pointer()
+	idx.Options.Max = plan.Max.ValueFloat64Pointer()
+	if !plan.BucketSize.IsNull() {
+		idx.Options.SetBucketSize(int32(plan.BucketSize.ValueInt64()))
+	}
+
+	if !plan.Weights.IsNull() {
+		var weights map[string]int64
+		resp.Diagnostics.Append(plan.Weights.ElementsAs(ctx, &weights, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		doc := bson.D{}
+		for _, field := range slices.Sorted(maps.Keys(weights)) {
+			doc = append(doc, bson.E{Key: field, Value: int32(weights[field])})
+		}
+		idx.Options.Weights = doc
+	}
 
 	if p := plan.Partial.ValueString(); p != "" {
 		var raw bson.Raw
-		if err := bson.UnmarshalExtJSON([]byte(p), true, &raw); err != nil {
+		if err := bson.UnmarshalExtJSON([]byte(p), false, &raw); err != nil {
 			resp.Diagnostics.AddError("invalid partial_filter_expression JSON", err.Error())
 			return
 		}
 		idx.Options.PartialFilterExpression = raw
 	}
 
-	name, err := indexes.CreateOne(ctx, idx)
+	if p := plan.WildcardProjection.ValueString(); p != "" {
+		var raw bson.Raw
+		if err := bson.UnmarshalExtJSON([]byte(p), false, &raw); err != nil {
+			resp.Diagnostics.AddError("invalid wildcard_projection JSON", err.Error())
+			return
+		}
+		idx.Options.SetWildcardProjection(raw)
+	}
+
+	createOpts := options.CreateIndexes()
+	if !plan.MaxTimeMS.IsNull() {
+		createOpts.SetMaxTime(time.Duration(plan.MaxTimeMS.ValueInt64()) * time.Millisecond)
+	}
+
+	var name string
+	err = retry.Do(ctx, r.client, func(ctx context.Context) error {
+		var err error
+		name, err = indexes.CreateOne(ctx, idx, createOpts)
+		return err
+	})
 	if err != nil {
+		if isMaxTimeExpired(err) {
+			resp.Diagnostics.AddError(
+				"create index failed",
+				fmt.Sprintf("The index build exceeded max_time_ms (%d ms) and was aborted by the server: %s", plan.MaxTimeMS.ValueInt64(), err),
+			)
+			return
+		}
+		if isIndexConflict(err) {
+			resp.Diagnostics.AddError("create index failed", conflictDetail(ctx, indexes, keys, idx.Options, err))
+			return
+		}
 		resp.Diagnostics.AddError("create index failed", err.Error())
 		return
 	}
 
 	plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", plan.Database.ValueString(), plan.Collection.ValueString(), name))
+	plan.Namespace = types.StringValue(fmt.Sprintf("%s.%s", plan.Database.ValueString(), plan.Collection.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -236,7 +529,8 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	}
 
 	indexes, err := ExIndexView{r.client.Database(state.Database.ValueString()).Collection(state.Collection.ValueString()).Indexes()}.ListExSpecifications(ctx)
-	if err != nil {
+	// Dropping the collection drops its indexes too
+	if err != nil && !isNamespaceNotFound(err) {
 		resp.Diagnostics.AddError("Failed to list index specifications", err.Error())
 		return
 	}
@@ -247,66 +541,141 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	// Only read non-defaults into state when attribute wasn't configured
-	if v := types.BoolPointerValue(index.Unique); v.ValueBool() || !state.Unique.IsNull() {
+	// Keys are only missing from state right after an import
+	imported := state.Keys == nil
+
+	// Only read non-defaults into state when attribute wasn't configured. After an import nothing was
+	// configured yet, so unique and sparse take the server value: both replace the index when configured.
+	if v := types.BoolValue(boolOrFalse(index.Unique)); v.ValueBool() || !state.Unique.IsNull() || imported {
 		state.Unique = v
 	}
-	if v := types.BoolPointerValue(index.Sparse); v.ValueBool() || !state.Sparse.IsNull() {
+	if v := types.BoolValue(boolOrFalse(index.Sparse)); v.ValueBool() || !state.Sparse.IsNull() || imported {
 		state.Sparse = v
 	}
+	if v := types.BoolValue(boolOrFalse(index.Hidden)); v.ValueBool() || !state.Hidden.IsNull() {
+		state.Hidden = v
+	}
 	if v := types.Int32PointerValue(index.ExpireAfterSeconds); v.ValueInt32() != 0 || !state.TTL.IsNull() {
 		state.TTL = v
 	}
 
 	if len(index.PartialFilterExpression) > 0 {
-		extJSON, err := bson.MarshalExtJSON(index.PartialFilterExpression, true, true)
+		// Relaxed mode keeps plain numbers (e.g. 5 instead of {"$numberInt":"5"}) so configured JSON round-trips
+		extJSON, err := bson.MarshalExtJSON(index.PartialFilterExpression, false, false)
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to marshal partial filter expression", err.Error())
 			return
 		}
 		state.Partial = jsontypes.NewNormalizedValue(string(extJSON))
+	} else {
+		// Don't keep a stale filter when the index was recreated without one
+		state.Partial = jsontypes.NewNormalizedNull()
 	}
 
-	var keysDoc bson.D
-	if err := bson.Unmarshal(index.KeysDocument, &keysDoc); err != nil {
-		resp.Diagnostics.AddError("Failed to decode index keys", err.Error())
+	if len(index.WildcardProjection) > 0 {
+		extJSON, err := bson.MarshalExtJSON(index.WildcardProjection, false, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to marshal wildcard projection", err.Error())
+			return
+		}
+		state.WildcardProjection = jsontypes.NewNormalizedValue(string(extJSON))
+	} else {
+		state.WildcardProjection = jsontypes.NewNormalizedNull()
+	}
+
+	if v := types.StringPointerValue(index.DefaultLanguage); v.ValueString() != defaultTextLanguage || !state.DefaultLanguage.IsNull() {
+		state.DefaultLanguage = v
+	}
+	if v := types.StringPointerValue(index.LanguageOverride); v.ValueString() != defaultTextLanguageOverride || !state.LanguageOverride.IsNull() {
+		state.LanguageOverride = v
+	}
+
+	if v := types.Int64Value(int64(int32OrZero(index.SphereIndexVersion))); (index.SphereIndexVersion != nil && v.ValueInt64() != defaultSphereIndexVersion) || !state.SphereIndexVersion.IsNull() {
+		state.SphereIndexVersion = v
+	}
+	if v := types.Int64Value(int64(int32OrZero(index.Bits))); (index.Bits != nil && v.ValueInt64() != defaultBits) || !state.Bits.IsNull() {
+		state.Bits = v
+	}
+	if v := types.Float64PointerValue(index.Min); (index.Min != nil && *index.Min != defaultMin) || !state.Min.IsNull() {
+		state.Min = v
+	}
+	if v := types.Float64PointerValue(index.Max); (index.Max != nil && *index.Max != defaultMax) || !state.Max.IsNull() {
+		state.Max = v
+	}
+	if index.BucketSize != nil {
+		state.BucketSize = types.Int64Value(int64(*index.BucketSize))
+	}
+
+	weights, err := index.decodeWeights()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to decode text index weights", err.Error())
 		return
 	}
-	state.Keys = make([]indexKeyModel, 0, len(keysDoc))
-	for _, e := range keysDoc {
-		var order int64
-		switch v := e.Value.(type) {
-		case int32:
-			order = int64(v)
-		case int64:
-			order = v
-		case float64:
-			order = int64(v)
-		default:
-			resp.Diagnostics.AddWarning(
-				"Non-numeric index key order encountered",
-				fmt.Sprintf("Field %q has unsupported type %T (value %v). Skipping.", e.Key, v, v),
-			)
-			continue
-		}
-		state.Keys = append(state.Keys, indexKeyModel{
-			Field: types.StringValue(e.Key),
-			Order: types.Int64Value(order),
-		})
+	var diags diag.Diagnostics
+	state.Weights, diags = readWeights(ctx, state.Weights, weights)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := index.decodeKeys()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to decode index keys", err.Error())
+		return
 	}
+	state.Keys = alignTextKeys(state.Keys, keys)
 
 	state.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", state.Database.ValueString(), state.Collection.ValueString(), state.Name.ValueString()))
+	state.Namespace = types.StringValue(fmt.Sprintf("%s.%s", state.Database.ValueString(), state.Collection.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// All meaningful changes are ForceNew semantics; just keep state
-	var plan ResourceModel
+	// Everything but hidden and ttl is ForceNew or provider-side only
+	var plan, state ResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := plan.Timeouts.UpdateContext(ctx, resourceTimeouts)
+	defer cancel()
+
+	if plan.Hidden.ValueBool() != state.Hidden.ValueBool() {
+		cmd := bson.D{
+			{Key: "collMod", Value: plan.Collection.ValueString()},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: plan.Name.ValueString()},
+				{Key: "hidden", Value: plan.Hidden.ValueBool()},
+			}},
+		}
+		err := retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return r.client.Database(plan.Database.ValueString()).RunCommand(ctx, cmd).Err()
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to change index visibility", err.Error())
+			return
+		}
+	}
+
+	if !plan.TTL.Equal(state.TTL) {
+		cmd := bson.D{
+			{Key: "collMod", Value: plan.Collection.ValueString()},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: plan.Name.ValueString()},
+				{Key: "expireAfterSeconds", Value: plan.TTL.ValueInt32()},
+			}},
+		}
+		err := retry.Do(ctx, r.client, func(ctx context.Context) error {
+			return r.client.Database(plan.Database.ValueString()).RunCommand(ctx, cmd).Err()
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to change index TTL", err.Error())
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -317,12 +686,44 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	ctx, cancel := state.Timeouts.DeleteContext(ctx, resourceTimeouts)
+	defer cancel()
+
 	if state.PreventDestroy.ValueBool() {
 		resp.Diagnostics.AddError("Prevented Index Deletion", "The index is marked as prevent_destroy, so it will not be deleted.")
 		return
 	}
 
-	if _, err := r.client.Database(state.Database.ValueString()).Collection(state.Collection.ValueString()).Indexes().DropOne(ctx, state.Name.ValueString()); err != nil {
+	coll := r.client.Database(state.Database.ValueString()).Collection(state.Collection.ValueString())
+
+	if !state.ForceDestroy.ValueBool() {
+		window := time.Duration(defaultUsageWindowSeconds) * time.Second
+		if !state.UsageWindow.IsNull() {
+			window = time.Duration(state.UsageWindow.ValueInt64()) * time.Second
+		}
+		ops, err := recentOps(ctx, coll, state.Name.ValueString(), window)
+		if err != nil && !isNamespaceNotFound(err) {
+			resp.Diagnostics.AddError("Failed to read index usage", err.Error())
+			return
+		}
+		if ops > 0 {
+			resp.Diagnostics.AddError(
+				"Index In Use",
+				fmt.Sprintf("Index %s was used %d times in the last %s. Set force_destroy = true to drop it anyway.", state.Name.ValueString(), ops, window),
+			)
+			return
+		}
+	}
+
+	err := retry.DoApplied(ctx, r.client, func(ctx context.Context) error {
+		_, err := coll.Indexes().DropOne(ctx, state.Name.ValueString())
+		return err
+	}, indexNotFoundCode)
+	if err != nil {
+		// The collection may already have been dropped together with its indexes
+		if isNamespaceNotFound(err) {
+			return
+		}
 		resp.Diagnostics.AddError("drop index failed", err.Error())
 	}
 }
@@ -337,21 +738,25 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		return
 	}
 
-	parts := strings.SplitN(id, "/", 3)
-	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+	parts, ok := importid.Split(id, 3)
+	if !ok {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			fmt.Sprintf("Expected 'database/collection/index', got %s", id),
+			fmt.Sprintf("Expected 'database/collection/index', with any '/' in a name encoded as %%2F, got %s", id),
 		)
 		return
 	}
 	db, coll, index := parts[0], parts[1], parts[2]
 
 	var state ResourceModel
-	state.ID = types.StringValue(id)
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", db, coll, index))
 	state.Name = types.StringValue(index)
 	state.Collection = types.StringValue(coll)
 	state.Database = types.StringValue(db)
+	state.Namespace = types.StringValue(fmt.Sprintf("%s.%s", db, coll))
+	state.ForceDestroy = types.BoolValue(true)
+	state.UsageWindow = types.Int64Value(defaultUsageWindowSeconds)
+	state.Weights = types.MapNull(types.Int64Type)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
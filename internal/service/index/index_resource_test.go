@@ -0,0 +1,239 @@
+package index
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestImportPlainIndex(t *testing.T) {
+	cases := map[string]struct {
+		spec   bson.D
+		unique bool
+		sparse bool
+	}{
+		"plain index": {
+			spec: indexSpec("a_1", bson.D{{Key: "a", Value: int32(1)}}),
+		},
+		"unique sparse index": {
+			spec: indexSpec("a_1", bson.D{{Key: "a", Value: int32(1)}},
+				bson.E{Key: "unique", Value: true},
+				bson.E{Key: "sparse", Value: true},
+			),
+			unique: true,
+			sparse: true,
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(listIndexesResponse(tc.spec))
+			state := importIndex(mt.T, mt.Client, "db/coll/a_1")
+			if state == nil {
+				mt.Fatal("imported index was removed from state")
+			}
+
+			// Both replace the index when configured, so they must match the server value even when false
+			if !state.Unique.Equal(types.BoolValue(tc.unique)) {
+				mt.Errorf("unique = %s, want %t", state.Unique, tc.unique)
+			}
+			if !state.Sparse.Equal(types.BoolValue(tc.sparse)) {
+				mt.Errorf("sparse = %s, want %t", state.Sparse, tc.sparse)
+			}
+			if len(state.Keys) != 1 || state.Keys[0].Field.ValueString() != "a" || state.Keys[0].Order.ValueInt64() != 1 {
+				mt.Errorf("keys = %+v, want a ascending", state.Keys)
+			}
+		})
+	}
+}
+
+func TestReadKeepsUnconfiguredFlagsNull(t *testing.T) {
+	mt := newMockTest(t)
+	mt.Run("plain index", func(mt *mtest.T) {
+		mt.AddMockResponses(listIndexesResponse(indexSpec("a_1", bson.D{{Key: "a", Value: int32(1)}})))
+		state := readIndex(mt.T, mt.Client, ResourceModel{
+			Database:   types.StringValue("db"),
+			Collection: types.StringValue("coll"),
+			Name:       types.StringValue("a_1"),
+			Keys:       []indexKeyModel{{Field: types.StringValue("a"), Order: types.Int64Value(1), Type: types.StringNull()}},
+			Weights:    types.MapNull(types.Int64Type),
+		})
+		if state == nil {
+			mt.Fatal("index was removed from state")
+		}
+		if !state.Unique.IsNull() || !state.Sparse.IsNull() {
+			mt.Errorf("unique = %s, sparse = %s, want both null", state.Unique, state.Sparse)
+		}
+	})
+}
+
+func TestReadWildcardProjection(t *testing.T) {
+	keys := bson.D{{Key: "$**", Value: int32(1)}}
+	prior := ResourceModel{
+		Database:           types.StringValue("db"),
+		Collection:         types.StringValue("coll"),
+		Name:               types.StringValue("wildcard"),
+		Keys:               []indexKeyModel{{Field: types.StringValue("$**"), Order: types.Int64Value(1), Type: types.StringNull()}},
+		Weights:            types.MapNull(types.Int64Type),
+		WildcardProjection: jsontypes.NewNormalizedValue(`{"a":1}`),
+	}
+
+	cases := map[string]struct {
+		spec bson.D
+		want jsontypes.Normalized
+	}{
+		"projection kept": {
+			spec: indexSpec("wildcard", keys, bson.E{Key: "wildcardProjection", Value: bson.D{{Key: "a", Value: int32(1)}}}),
+			want: jsontypes.NewNormalizedValue(`{"a":1}`),
+		},
+		"projection removed out of band": {
+			spec: indexSpec("wildcard", keys),
+			want: jsontypes.NewNormalizedNull(),
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(listIndexesResponse(tc.spec))
+			state := readIndex(mt.T, mt.Client, prior)
+			if state == nil {
+				mt.Fatal("index was removed from state")
+			}
+			if !state.WildcardProjection.Equal(tc.want) {
+				mt.Errorf("wildcard_projection = %s, want %s", state.WildcardProjection, tc.want)
+			}
+		})
+	}
+}
+
+// TestMixedGeoTextKeys checks that ordered, geospatial and text keys are sent in their declared order and read
+// back unchanged, although the server stores the text field as _fts/_ftsx.
+func TestMixedGeoTextKeys(t *testing.T) {
+	plan := ResourceModel{
+		Database:   types.StringValue("db"),
+		Collection: types.StringValue("coll"),
+		Name:       types.StringValue("mixed"),
+		Keys: []indexKeyModel{
+			{Field: types.StringValue("a"), Order: types.Int64Value(-1), Type: types.StringNull()},
+			{Field: types.StringValue("b"), Order: types.Int64Null(), Type: types.StringValue("2dsphere")},
+			{Field: types.StringValue("c"), Order: types.Int64Null(), Type: types.StringValue(textKeyType)},
+		},
+		Weights: types.MapNull(types.Int64Type),
+	}
+
+	mt := newMockTest(t)
+	mt.Run("create", func(mt *mtest.T) {
+		mt.AddMockResponses(listIndexesResponse(idIndexSpec), mtest.CreateSuccessResponse())
+
+		r := &Resource{client: mt.Client}
+		planState := newState(mt.T, r, &plan)
+		resp := resource.CreateResponse{State: newState(mt.T, r, nil)}
+		r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}, &resp)
+		if resp.Diagnostics.HasError() {
+			mt.Fatalf("create: %v", resp.Diagnostics)
+		}
+
+		events := mt.GetAllStartedEvents()
+		if got := commandNames(mt); !slices.Equal(got, []string{"listIndexes", "createIndexes"}) {
+			mt.Fatalf("commands = %v, want listIndexes and createIndexes", got)
+		}
+		var cmd struct {
+			Indexes []struct {
+				Key bson.D `bson:"key"`
+			} `bson:"indexes"`
+		}
+		if err := bson.Unmarshal(events[1].Command, &cmd); err != nil {
+			mt.Fatal(err)
+		}
+		want := bson.D{{Key: "a", Value: int32(-1)}, {Key: "b", Value: "2dsphere"}, {Key: "c", Value: textKeyType}}
+		if len(cmd.Indexes) != 1 || !reflect.DeepEqual(cmd.Indexes[0].Key, want) {
+			mt.Errorf("createIndexes keys = %v, want %v", cmd.Indexes, want)
+		}
+	})
+
+	mt.Run("read", func(mt *mtest.T) {
+		mt.AddMockResponses(listIndexesResponse(indexSpec("mixed",
+			bson.D{
+				{Key: "a", Value: int32(-1)},
+				{Key: "b", Value: "2dsphere"},
+				{Key: "_fts", Value: "text"},
+				{Key: "_ftsx", Value: int32(1)},
+			},
+			bson.E{Key: "weights", Value: bson.D{{Key: "c", Value: int32(1)}}},
+			bson.E{Key: "default_language", Value: defaultTextLanguage},
+			bson.E{Key: "language_override", Value: defaultTextLanguageOverride},
+			bson.E{Key: "textIndexVersion", Value: int32(3)},
+			bson.E{Key: "2dsphereIndexVersion", Value: int32(3)},
+		)))
+
+		state := readIndex(mt.T, mt.Client, plan)
+		if state == nil {
+			mt.Fatal("index was removed from state")
+		}
+		if !reflect.DeepEqual(state.Keys, plan.Keys) {
+			mt.Errorf("keys = %+v, want %+v", state.Keys, plan.Keys)
+		}
+	})
+}
+
+// TestReadRemovesMissingIndex checks that an index dropped outside Terraform, directly or with its collection, is
+// removed from state without an error so the next plan recreates it.
+func TestReadRemovesMissingIndex(t *testing.T) {
+	prior := ResourceModel{
+		Database:   types.StringValue("db"),
+		Collection: types.StringValue("coll"),
+		Name:       types.StringValue("a_1"),
+		Keys:       []indexKeyModel{{Field: types.StringValue("a"), Order: types.Int64Value(1), Type: types.StringNull()}},
+		Weights:    types.MapNull(types.Int64Type),
+	}
+
+	cases := map[string]bson.D{
+		"index dropped": listIndexesResponse(idIndexSpec),
+		"collection dropped": mtest.CreateCommandErrorResponse(mtest.CommandError{
+			Code: namespaceNotFoundCode, Name: "NamespaceNotFound", Message: "ns does not exist: db.coll",
+		}),
+	}
+
+	mt := newMockTest(t)
+	for name, response := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(response)
+			if state := readIndex(mt.T, mt.Client, prior); state != nil {
+				mt.Errorf("read kept %+v, want the resource removed", state)
+			}
+		})
+	}
+}
+
+func TestReadListIndexesError(t *testing.T) {
+	mt := newMockTest(t)
+	mt.Run("unauthorized", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 13, Name: "Unauthorized", Message: "not authorized"}))
+
+		r := &Resource{client: mt.Client}
+		state := newState(mt.T, r, &ResourceModel{
+			Database:   types.StringValue("db"),
+			Collection: types.StringValue("coll"),
+			Name:       types.StringValue("a_1"),
+			Weights:    types.MapNull(types.Int64Type),
+		})
+		resp := resource.ReadResponse{State: state}
+		r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+		if !resp.Diagnostics.HasError() {
+			mt.Error("read succeeded although listIndexes failed")
+		}
+		if resp.State.Raw.IsNull() {
+			mt.Error("read removed the resource although listIndexes failed")
+		}
+	})
+}
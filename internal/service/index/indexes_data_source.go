@@ -0,0 +1,174 @@
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ListDataSource{}
+var _ datasource.DataSourceWithConfigure = &ListDataSource{}
+
+func NewListDataSource() datasource.DataSource {
+	return &ListDataSource{}
+}
+
+type ListDataSource struct {
+	client *mongo.Client
+}
+
+type indexEntryModel struct {
+	Name    types.String         `tfsdk:"name"`
+	Keys    []indexKeyModel      `tfsdk:"keys"`
+	Unique  types.Bool           `tfsdk:"unique"`
+	Sparse  types.Bool           `tfsdk:"sparse"`
+	TTL     types.Int32          `tfsdk:"ttl"`
+	Partial jsontypes.Normalized `tfsdk:"partial_filter_expression"`
+}
+
+type ListDataSourceModel struct {
+	ID         types.String      `tfsdk:"id"`
+	Database   types.String      `tfsdk:"database"`
+	Collection types.String      `tfsdk:"collection"`
+	Indexes    []indexEntryModel `tfsdk:"indexes"`
+}
+
+func (d *ListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_indexes"
+}
+
+func (d *ListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all indexes of a MongoDB collection.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "Database name.",
+			},
+			"collection": schema.StringAttribute{
+				Required:    true,
+				Description: "Collection name.",
+			},
+			"indexes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Indexes of the collection, in the order the server lists them.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Index name.",
+						},
+						"keys": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Indexed fields, in order.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"field": schema.StringAttribute{
+										Computed: true,
+									},
+									"order": schema.Int64Attribute{
+										Computed: true,
+									},
+									"type": schema.StringAttribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+						"unique": schema.BoolAttribute{
+							Computed:    true,
+							Description: "If true, the index enforces a uniqueness constraint on the indexed field(s).",
+						},
+						"sparse": schema.BoolAttribute{
+							Computed:    true,
+							Description: "If true, the index only includes documents that have the indexed field(s).",
+						},
+						"ttl": schema.Int32Attribute{
+							Computed:    true,
+							Description: "Time-to-live in seconds for the index, if it is a TTL index.",
+						},
+						"partial_filter_expression": schema.StringAttribute{
+							CustomType:  jsontypes.NormalizedType{},
+							Computed:    true,
+							Description: "JSON string for partial filter expression.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ListDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan ListDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	indexes, err := ExIndexView{d.client.Database(plan.Database.ValueString()).Collection(plan.Collection.ValueString()).Indexes()}.ListExSpecifications(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list index specifications", err.Error())
+		return
+	}
+
+	plan.Indexes = make([]indexEntryModel, 0, len(indexes))
+	for _, index := range indexes {
+		if index == nil {
+			continue
+		}
+
+		entry := indexEntryModel{
+			Name:    types.StringValue(index.Name),
+			Unique:  types.BoolValue(boolOrFalse(index.Unique)),
+			Sparse:  types.BoolValue(boolOrFalse(index.Sparse)),
+			TTL:     types.Int32PointerValue(index.ExpireAfterSeconds),
+			Partial: jsontypes.NewNormalizedNull(),
+		}
+		if len(index.PartialFilterExpression) > 0 {
+			// Relaxed mode keeps plain numbers (e.g. 5 instead of {"$numberInt":"5"}) so configured JSON round-trips
+			extJSON, err := bson.MarshalExtJSON(index.PartialFilterExpression, false, false)
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to marshal partial filter expression", fmt.Sprintf("%s: %s", index.Name, err))
+				return
+			}
+			entry.Partial = jsontypes.NewNormalizedValue(string(extJSON))
+		}
+		if entry.Keys, err = index.decodeKeys(); err != nil {
+			resp.Diagnostics.AddError("Failed to decode index keys", fmt.Sprintf("%s: %s", index.Name, err))
+			return
+		}
+		plan.Indexes = append(plan.Indexes, entry)
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Collection.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
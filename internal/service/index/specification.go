@@ -2,7 +2,10 @@ package index
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -19,6 +22,62 @@ func (eis ExIndexSpecifications) Find(name string) *ExIndexSpecification {
 	return nil
 }
 
+// FindByKeyPrefix returns the first index whose leading keys match prefix in order. Prefix entries without an
+// order or type match on the field alone.
+func (eis ExIndexSpecifications) FindByKeyPrefix(prefix []indexKeyModel) (*ExIndexSpecification, error) {
+	for _, i := range eis {
+		if i == nil {
+			continue
+		}
+
+		keys, err := i.decodeKeys()
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) < len(prefix) {
+			continue
+		}
+
+		matches := true
+		for n, k := range prefix {
+			if !keyMatches(keys[n], k) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return i, nil
+		}
+	}
+	return nil, nil
+}
+
+// keyMatches reports whether key matches a key_prefix entry. An entry without an order or type matches the field
+// whatever its order or type.
+func keyMatches(key, prefix indexKeyModel) bool {
+	if !key.Field.Equal(prefix.Field) {
+		return false
+	}
+	if !prefix.Order.IsNull() && !key.Order.Equal(prefix.Order) {
+		return false
+	}
+	return prefix.Type.IsNull() || key.Type.Equal(prefix.Type)
+}
+
+// numericOrder converts an ascending/descending key value into an int64.
+func numericOrder(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
 // ExIndexSpecification represents the specification of a MongoDB index.
 // mongo.IndexSpecification has missing fields like PartialFilterExpression, so we define our own.
 type ExIndexSpecification struct {
@@ -31,6 +90,89 @@ type ExIndexSpecification struct {
 	Unique                  *bool    `bson:"unique"`
 	Clustered               *bool    `bson:"clustered"`
 	PartialFilterExpression bson.Raw `bson:"partialFilterExpression"`
+	Weights                 bson.Raw `bson:"weights"`
+	DefaultLanguage         *string  `bson:"default_language"`
+	LanguageOverride        *string  `bson:"language_override"`
+	SphereIndexVersion      *int32   `bson:"2dsphereIndexVersion"`
+	Bits                    *int32   `bson:"bits"`
+	Min                     *float64 `bson:"min"`
+	Max                     *float64 `bson:"max"`
+	BucketSize              *float64 `bson:"bucketSize"`
+	WildcardProjection      bson.Raw `bson:"wildcardProjection"`
+	Hidden                  *bool    `bson:"hidden"`
+	Collation               bson.Raw `bson:"collation"`
+}
+
+// wildcardField is the key of a wildcard index over all fields, the only one that takes a wildcardProjection.
+const wildcardField = "$**"
+
+const (
+	// textKeyType is the key type of text index fields.
+	textKeyType = "text"
+
+	defaultTextLanguage         = "english"
+	defaultTextLanguageOverride = "language"
+)
+
+// decodeKeys converts the stored key document back into the configured keys. Text indexes are stored
+// as the internal _fts/_ftsx keys with the text fields listed in weights, so those are expanded back.
+func (eis *ExIndexSpecification) decodeKeys() ([]indexKeyModel, error) {
+	var keysDoc bson.D
+	if err := bson.Unmarshal(eis.KeysDocument, &keysDoc); err != nil {
+		return nil, err
+	}
+
+	keys := make([]indexKeyModel, 0, len(keysDoc))
+	for _, e := range keysDoc {
+		switch e.Key {
+		case "_fts":
+			weights, err := eis.decodeWeights()
+			if err != nil {
+				return nil, err
+			}
+			for _, w := range weights {
+				keys = append(keys, indexKeyModel{
+					Field: types.StringValue(w.Key),
+					Order: types.Int64Null(),
+					Type:  types.StringValue(textKeyType),
+				})
+			}
+			continue
+		case "_ftsx":
+			continue
+		}
+
+		if order, ok := numericOrder(e.Value); ok {
+			keys = append(keys, indexKeyModel{
+				Field: types.StringValue(e.Key),
+				Order: types.Int64Value(order),
+				Type:  types.StringNull(),
+			})
+			continue
+		}
+		keyType, ok := e.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("field %q has unsupported key value %v (%T)", e.Key, e.Value, e.Value)
+		}
+		keys = append(keys, indexKeyModel{
+			Field: types.StringValue(e.Key),
+			Order: types.Int64Null(),
+			Type:  types.StringValue(keyType),
+		})
+	}
+	return keys, nil
+}
+
+// decodeWeights returns the text index weights in stored order.
+func (eis *ExIndexSpecification) decodeWeights() (bson.D, error) {
+	var weights bson.D
+	if len(eis.Weights) == 0 {
+		return weights, nil
+	}
+	if err := bson.Unmarshal(eis.Weights, &weights); err != nil {
+		return nil, err
+	}
+	return weights, nil
 }
 
 type ExIndexView struct {
@@ -51,3 +193,40 @@ func (eiv ExIndexView) ListExSpecifications(ctx context.Context, opts ...*option
 
 	return results, nil
 }
+
+// namespaceNotFoundCode is the server error code returned when the target collection does not exist.
+const namespaceNotFoundCode = 26
+
+func isNamespaceNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == namespaceNotFoundCode || cmdErr.Name == "NamespaceNotFound"
+	}
+	return false
+}
+
+// maxTimeExpiredCode is the server error code returned when an operation exceeds its maxTimeMS.
+const maxTimeExpiredCode = 50
+
+func isMaxTimeExpired(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == maxTimeExpiredCode || cmdErr.Name == "MaxTimeMSExpired"
+	}
+	return false
+}
+
+// boolOrFalse reads an optional index flag; the server omits unique/sparse/hidden when they are false.
+func boolOrFalse(v *bool) bool {
+	return v != nil && *v
+}
+
+// hasWildcardKey reports whether keys contain the all-fields wildcard key, or may once unknowns resolve.
+func hasWildcardKey(keys []indexKeyModel) bool {
+	for _, k := range keys {
+		if k.Field.IsUnknown() || k.Field.ValueString() == wildcardField {
+			return true
+		}
+	}
+	return false
+}
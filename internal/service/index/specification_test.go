@@ -0,0 +1,91 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// specification decodes an index specification as listIndexes returns it.
+func specification(t *testing.T, spec bson.D) *ExIndexSpecification {
+	t.Helper()
+	raw, err := bson.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var eis ExIndexSpecification
+	if err := bson.Unmarshal(raw, &eis); err != nil {
+		t.Fatal(err)
+	}
+	return &eis
+}
+
+func orderedKey(field string, order int64) indexKeyModel {
+	return indexKeyModel{Field: types.StringValue(field), Order: types.Int64Value(order), Type: types.StringNull()}
+}
+
+func typedKey(field, keyType string) indexKeyModel {
+	return indexKeyModel{Field: types.StringValue(field), Order: types.Int64Null(), Type: types.StringValue(keyType)}
+}
+
+func fieldKey(field string) indexKeyModel {
+	return indexKeyModel{Field: types.StringValue(field), Order: types.Int64Null(), Type: types.StringNull()}
+}
+
+func TestFindByKeyPrefix(t *testing.T) {
+	indexes := ExIndexSpecifications{
+		specification(t, indexSpec("_id_", bson.D{{Key: "_id", Value: int32(1)}})),
+		specification(t, indexSpec("a_1_b_-1", bson.D{{Key: "a", Value: int32(1)}, {Key: "b", Value: int32(-1)}})),
+		specification(t, indexSpec("a_-1", bson.D{{Key: "a", Value: int64(-1)}})),
+		specification(t, indexSpec("loc_2dsphere", bson.D{{Key: "loc", Value: "2dsphere"}, {Key: "c", Value: 1.0}})),
+		specification(t, indexSpec("title_text", bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}},
+			bson.E{Key: "weights", Value: bson.D{{Key: "title", Value: int32(1)}}})),
+	}
+
+	cases := map[string]struct {
+		prefix []indexKeyModel
+		want   string
+	}{
+		"full keys":            {prefix: []indexKeyModel{orderedKey("a", 1), orderedKey("b", -1)}, want: "a_1_b_-1"},
+		"leading key":          {prefix: []indexKeyModel{orderedKey("a", 1)}, want: "a_1_b_-1"},
+		"order decides":        {prefix: []indexKeyModel{orderedKey("a", -1)}, want: "a_-1"},
+		"field only":           {prefix: []indexKeyModel{fieldKey("a")}, want: "a_1_b_-1"},
+		"field only second":    {prefix: []indexKeyModel{fieldKey("a"), fieldKey("b")}, want: "a_1_b_-1"},
+		"key type":             {prefix: []indexKeyModel{typedKey("loc", "2dsphere")}, want: "loc_2dsphere"},
+		"key type then order":  {prefix: []indexKeyModel{typedKey("loc", "2dsphere"), orderedKey("c", 1)}, want: "loc_2dsphere"},
+		"text field":           {prefix: []indexKeyModel{typedKey("title", "text")}, want: "title_text"},
+		"wrong order":          {prefix: []indexKeyModel{orderedKey("b", -1)}},
+		"wrong type":           {prefix: []indexKeyModel{typedKey("loc", "2d")}},
+		"longer than any keys": {prefix: []indexKeyModel{orderedKey("a", 1), orderedKey("b", -1), orderedKey("c", 1)}},
+		"out of order":         {prefix: []indexKeyModel{orderedKey("b", -1), orderedKey("a", 1)}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := indexes.FindByKeyPrefix(tc.prefix)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.want == "" {
+				if got != nil {
+					t.Errorf("FindByKeyPrefix = %s, want no match", got.Name)
+				}
+				return
+			}
+			if got == nil || got.Name != tc.want {
+				t.Errorf("FindByKeyPrefix = %v, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindByKeyPrefixInvalidKeys(t *testing.T) {
+	indexes := ExIndexSpecifications{
+		nil,
+		specification(t, indexSpec("bad", bson.D{{Key: "a", Value: true}})),
+	}
+	if _, err := indexes.FindByKeyPrefix([]indexKeyModel{fieldKey("a")}); err == nil {
+		t.Error("expected an error for an unsupported key value")
+	}
+}
@@ -0,0 +1,67 @@
+package index
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// alignTextKeys orders decoded text keys like the prior keys when both cover the same text fields,
+// since the server doesn't keep the order text fields were declared in.
+func alignTextKeys(prior, decoded []indexKeyModel) []indexKeyModel {
+	var priorText []indexKeyModel
+	for _, k := range prior {
+		if k.Type.ValueString() == textKeyType {
+			priorText = append(priorText, k)
+		}
+	}
+
+	decodedText := map[string]bool{}
+	for _, k := range decoded {
+		if k.Type.ValueString() == textKeyType {
+			decodedText[k.Field.ValueString()] = true
+		}
+	}
+	if len(priorText) != len(decodedText) {
+		return decoded
+	}
+	for _, k := range priorText {
+		if !decodedText[k.Field.ValueString()] {
+			return decoded
+		}
+	}
+
+	aligned := make([]indexKeyModel, 0, len(decoded))
+	next := 0
+	for _, k := range decoded {
+		if k.Type.ValueString() == textKeyType {
+			k = priorText[next]
+			next++
+		}
+		aligned = append(aligned, k)
+	}
+	return aligned
+}
+
+// readWeights returns the weights to keep in state. The server stores a weight for every text field
+// (1 by default), so only configured fields and non-default weights are kept.
+func readWeights(ctx context.Context, prior types.Map, weights bson.D) (types.Map, diag.Diagnostics) {
+	configured := prior.Elements()
+
+	values := map[string]int64{}
+	for _, w := range weights {
+		weight, ok := numericOrder(w.Value)
+		if !ok {
+			continue
+		}
+		if _, ok := configured[w.Key]; ok || weight != 1 {
+			values[w.Key] = weight
+		}
+	}
+	if len(values) == 0 && prior.IsNull() {
+		return types.MapNull(types.Int64Type), nil
+	}
+	return types.MapValueFrom(ctx, types.Int64Type, values)
+}
@@ -0,0 +1,191 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// defaultDuplicateSampleSize is how many duplicate groups are returned when sample_size is not set.
+const defaultDuplicateSampleSize = 10
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UniquenessCheckDataSource{}
+var _ datasource.DataSourceWithConfigure = &UniquenessCheckDataSource{}
+
+func NewUniquenessCheckDataSource() datasource.DataSource {
+	return &UniquenessCheckDataSource{}
+}
+
+type UniquenessCheckDataSource struct {
+	client *mongo.Client
+}
+
+type duplicateGroupModel struct {
+	Key   jsontypes.Normalized `tfsdk:"key"`
+	Count types.Int64          `tfsdk:"count"`
+}
+
+type UniquenessCheckDataSourceModel struct {
+	ID            types.String          `tfsdk:"id"`
+	Database      types.String          `tfsdk:"database"`
+	Collection    types.String          `tfsdk:"collection"`
+	Fields        []types.String        `tfsdk:"fields"`
+	SampleSize    types.Int64           `tfsdk:"sample_size"`
+	HasDuplicates types.Bool            `tfsdk:"has_duplicates"`
+	Duplicates    []duplicateGroupModel `tfsdk:"duplicates"`
+}
+
+func (d *UniquenessCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index_uniqueness_check"
+}
+
+func (d *UniquenessCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether a unique index on the given fields could be built, by looking for documents sharing the same key values.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "Database name.",
+			},
+			"collection": schema.StringAttribute{
+				Required:    true,
+				Description: "Collection name.",
+			},
+			"fields": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Fields the unique index would cover.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"sample_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Maximum number of duplicate groups to return. (Default: %d)", defaultDuplicateSampleSize),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"has_duplicates": schema.BoolAttribute{
+				Computed:    true,
+				Description: "True if at least two documents share the same values for the fields.",
+			},
+			"duplicates": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Sample of duplicate key groups.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							CustomType:  jsontypes.NormalizedType{},
+							Computed:    true,
+							Description: "JSON document of the duplicated field values.",
+						},
+						"count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of documents sharing the key.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UniquenessCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UniquenessCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan UniquenessCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sampleSize := int64(defaultDuplicateSampleSize)
+	if !plan.SampleSize.IsNull() {
+		sampleSize = plan.SampleSize.ValueInt64()
+	}
+
+	// Group keys can't contain dots, so fields are grouped positionally and renamed afterwards
+	groupID := bson.D{}
+	fields := make([]string, 0, len(plan.Fields))
+	for i, f := range plan.Fields {
+		fields = append(fields, f.ValueString())
+		groupID = append(groupID, bson.E{Key: fmt.Sprintf("k%d", i), Value: "$" + f.ValueString()})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: groupID},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "count", Value: bson.D{{Key: "$gt", Value: 1}}}}}},
+		{{Key: "$limit", Value: sampleSize}},
+	}
+
+	coll := d.client.Database(plan.Database.ValueString()).Collection(plan.Collection.ValueString())
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to check for duplicates", err.Error())
+		return
+	}
+
+	var groups []struct {
+		ID    bson.D `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		resp.Diagnostics.AddError("Failed to decode duplicate groups", err.Error())
+		return
+	}
+
+	plan.Duplicates = make([]duplicateGroupModel, 0, len(groups))
+	for _, g := range groups {
+		key := bson.D{}
+		for i, e := range g.ID {
+			key = append(key, bson.E{Key: fields[i], Value: e.Value})
+		}
+		extJSON, err := bson.MarshalExtJSON(key, false, false)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to marshal duplicate key", err.Error())
+			return
+		}
+		plan.Duplicates = append(plan.Duplicates, duplicateGroupModel{
+			Key:   jsontypes.NewNormalizedValue(string(extJSON)),
+			Count: types.Int64Value(g.Count),
+		})
+	}
+	plan.HasDuplicates = types.BoolValue(len(groups) > 0)
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", plan.Database.ValueString(), plan.Collection.ValueString(), strings.Join(fields, ",")))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
@@ -0,0 +1,111 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// checkUniqueness runs the uniqueness check data source Read for fields on db.coll and returns the result.
+func checkUniqueness(t *testing.T, mt *mtest.T, sampleSize types.Int64, fields ...string) UniquenessCheckDataSourceModel {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	(&UniquenessCheckDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	config := UniquenessCheckDataSourceModel{
+		ID:            types.StringNull(),
+		Database:      types.StringValue("db"),
+		Collection:    types.StringValue("coll"),
+		SampleSize:    sampleSize,
+		HasDuplicates: types.BoolNull(),
+	}
+	for _, f := range fields {
+		config.Fields = append(config.Fields, types.StringValue(f))
+	}
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("set config: %v", diags)
+	}
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}
+	resp := datasource.ReadResponse{State: state}
+	(&UniquenessCheckDataSource{client: mt.Client}).Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read: %v", resp.Diagnostics)
+	}
+
+	var model UniquenessCheckDataSourceModel
+	getState(t, resp.State, &model)
+	return model
+}
+
+func TestUniquenessCheck(t *testing.T) {
+	mt := newMockTest(t)
+
+	mt.Run("duplicates", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch,
+			bson.D{
+				{Key: "_id", Value: bson.D{{Key: "k0", Value: "a@example.com"}, {Key: "k1", Value: int32(7)}}},
+				{Key: "count", Value: int32(3)},
+			},
+		))
+
+		model := checkUniqueness(mt.T, mt, types.Int64Value(5), "email", "profile.tenant")
+
+		pipeline := startedCommand(mt, "aggregate").Lookup("pipeline").Array()
+		group := pipeline.Index(0).Value().Document().Lookup("$group", "_id").Document()
+		if got := group.Lookup("k0").StringValue(); got != "$email" {
+			mt.Errorf("k0 = %q, want $email", got)
+		}
+		if got := group.Lookup("k1").StringValue(); got != "$profile.tenant" {
+			mt.Errorf("k1 = %q, want $profile.tenant", got)
+		}
+		if gt, ok := pipeline.Index(1).Value().Document().Lookup("$match", "count", "$gt").AsInt64OK(); !ok || gt != 1 {
+			mt.Errorf("$match = %s, want count > 1", pipeline.Index(1).Value())
+		}
+		if limit, ok := pipeline.Index(2).Value().Document().Lookup("$limit").AsInt64OK(); !ok || limit != 5 {
+			mt.Errorf("$limit = %s, want 5", pipeline.Index(2).Value())
+		}
+
+		if !model.HasDuplicates.ValueBool() {
+			mt.Error("has_duplicates = false with a duplicate group")
+		}
+		if len(model.Duplicates) != 1 {
+			mt.Fatalf("duplicates = %+v, want one group", model.Duplicates)
+		}
+		if got, want := model.Duplicates[0].Key.ValueString(), `{"email":"a@example.com","profile.tenant":7}`; got != want {
+			mt.Errorf("key = %s, want %s", got, want)
+		}
+		if got := model.Duplicates[0].Count.ValueInt64(); got != 3 {
+			mt.Errorf("count = %d, want 3", got)
+		}
+		if got := model.ID.ValueString(); got != "db/coll/email,profile.tenant" {
+			mt.Errorf("id = %q", got)
+		}
+	})
+
+	mt.Run("no duplicates", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch))
+
+		model := checkUniqueness(mt.T, mt, types.Int64Null(), "email")
+
+		limit := startedCommand(mt, "aggregate").Lookup("pipeline").Array().Index(2).Value().Document().Lookup("$limit")
+		if got, ok := limit.AsInt64OK(); !ok || got != defaultDuplicateSampleSize {
+			mt.Errorf("$limit = %s, want %d", limit, defaultDuplicateSampleSize)
+		}
+		if model.HasDuplicates.IsNull() || model.HasDuplicates.ValueBool() {
+			mt.Errorf("has_duplicates = %s, want false", model.HasDuplicates)
+		}
+		if model.Duplicates == nil || len(model.Duplicates) != 0 {
+			mt.Errorf("duplicates = %#v, want an empty list", model.Duplicates)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// defaultUsageWindowSeconds is how far back the force_destroy guard looks for accesses by default.
+const defaultUsageWindowSeconds = 10
+
+// indexUsage is one $indexStats entry: usage of an index on a single host (or shard).
+type indexUsage struct {
+	Name     string `bson:"name"`
+	Host     string `bson:"host"`
+	Shard    string `bson:"shard"`
+	Accesses struct {
+		Ops   int64     `bson:"ops"`
+		Since time.Time `bson:"since"`
+	} `bson:"accesses"`
+}
+
+// listIndexUsage runs $indexStats on the collection, optionally limited to a single index.
+func listIndexUsage(ctx context.Context, coll *mongo.Collection, name string) ([]indexUsage, error) {
+	pipeline := mongo.Pipeline{{{Key: "$indexStats", Value: bson.D{}}}}
+	if name != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "name", Value: name}}}})
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var usage []indexUsage
+	if err := cursor.All(ctx, &usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// totalOps sums the accesses of an index across all hosts reporting it, with the earliest counting start.
+func totalOps(usage []indexUsage) (ops int64, since time.Time) {
+	for _, u := range usage {
+		ops += u.Accesses.Ops
+		if since.IsZero() || u.Accesses.Since.Before(since) {
+			since = u.Accesses.Since
+		}
+	}
+	return ops, since
+}
+
+// recentOps returns the accesses of the named index during the last window. $indexStats only counts accesses
+// since its counters started, e.g. at the last restart, so unless they started within the window the index is
+// sampled again once a fresh window has passed. It fails without waiting when ctx ends before that.
+func recentOps(ctx context.Context, coll *mongo.Collection, name string, window time.Duration) (int64, error) {
+	usage, err := listIndexUsage(ctx, coll, name)
+	if err != nil || len(usage) == 0 {
+		return 0, err
+	}
+	ops, since := totalOps(usage)
+	if time.Since(since) <= window {
+		return ops, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < window {
+		return 0, fmt.Errorf("measuring usage of index %s takes %s, longer than the time left before the timeout", name, window)
+	}
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-time.After(window):
+	}
+	usage, err = listIndexUsage(ctx, coll, name)
+	if err != nil {
+		return 0, err
+	}
+	later, laterSince := totalOps(usage)
+	// Counters that restarted in between only hold accesses from within the window
+	if laterSince.After(since) || later < ops {
+		return later, nil
+	}
+	return later - ops, nil
+}
@@ -0,0 +1,137 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsageDataSource{}
+var _ datasource.DataSourceWithConfigure = &UsageDataSource{}
+
+func NewUsageDataSource() datasource.DataSource {
+	return &UsageDataSource{}
+}
+
+type UsageDataSource struct {
+	client *mongo.Client
+}
+
+type indexUsageModel struct {
+	Name  types.String `tfsdk:"name"`
+	Ops   types.Int64  `tfsdk:"ops"`
+	Since types.String `tfsdk:"since"`
+}
+
+type UsageDataSourceModel struct {
+	ID         types.String      `tfsdk:"id"`
+	Database   types.String      `tfsdk:"database"`
+	Collection types.String      `tfsdk:"collection"`
+	Indexes    []indexUsageModel `tfsdk:"indexes"`
+}
+
+func (d *UsageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_index_usage"
+}
+
+func (d *UsageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports how often each index of a collection has been used, from the $indexStats aggregation.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "Database name.",
+			},
+			"collection": schema.StringAttribute{
+				Required:    true,
+				Description: "Collection name.",
+			},
+			"indexes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Usage of each index, sorted by name. Counts are summed across all hosts and shards reporting the index.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Index name.",
+						},
+						"ops": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of operations that used the index (accesses.ops).",
+						},
+						"since": schema.StringAttribute{
+							Computed:    true,
+							Description: "RFC 3339 time usage counting started (accesses.since); counters reset on restart or index rebuild.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan UsageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	coll := d.client.Database(plan.Database.ValueString()).Collection(plan.Collection.ValueString())
+	usage, err := listIndexUsage(ctx, coll, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read index usage", err.Error())
+		return
+	}
+
+	byName := map[string][]indexUsage{}
+	for _, u := range usage {
+		byName[u.Name] = append(byName[u.Name], u)
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	plan.Indexes = make([]indexUsageModel, 0, len(names))
+	for _, name := range names {
+		ops, since := totalOps(byName[name])
+		plan.Indexes = append(plan.Indexes, indexUsageModel{
+			Name:  types.StringValue(name),
+			Ops:   types.Int64Value(ops),
+			Since: types.StringValue(since.UTC().Format(time.RFC3339)),
+		})
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Collection.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
@@ -0,0 +1,113 @@
+package index
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// indexStatsResponse is a mocked $indexStats reply for the index a_1 with the given access counters.
+func indexStatsResponse(ops int64, since time.Time) bson.D {
+	return mtest.CreateCursorResponse(0, "db.coll", mtest.FirstBatch, bson.D{
+		{Key: "name", Value: "a_1"},
+		{Key: "host", Value: "localhost:27017"},
+		{Key: "accesses", Value: bson.D{{Key: "ops", Value: ops}, {Key: "since", Value: since}}},
+	})
+}
+
+// deleteIndex runs the index resource Delete for state and returns its diagnostics.
+func deleteIndex(t *testing.T, mt *mtest.T, state ResourceModel) resource.DeleteResponse {
+	t.Helper()
+	r := &Resource{client: mt.Client}
+	req := resource.DeleteRequest{State: newState(t, r, &state)}
+	resp := resource.DeleteResponse{State: req.State}
+	r.Delete(context.Background(), req, &resp)
+	return resp
+}
+
+func TestForceDestroyGuard(t *testing.T) {
+	guarded := ResourceModel{
+		Database:     types.StringValue("db"),
+		Collection:   types.StringValue("coll"),
+		Name:         types.StringValue("a_1"),
+		Keys:         []indexKeyModel{{Field: types.StringValue("a"), Order: types.Int64Value(1), Type: types.StringNull()}},
+		Weights:      types.MapNull(types.Int64Type),
+		ForceDestroy: types.BoolValue(false),
+		UsageWindow:  types.Int64Value(1),
+	}
+	cases := map[string]struct {
+		// ops are the counters of successive samples, which started sinceAgo before the first one
+		ops      []int64
+		sinceAgo time.Duration
+		wantErr  bool
+	}{
+		"used since a recent restart": {
+			ops:     []int64{5},
+			wantErr: true,
+		},
+		"unused since a recent restart": {
+			ops: []int64{0},
+		},
+		"used long ago only": {
+			ops:      []int64{100, 100},
+			sinceAgo: 24 * time.Hour,
+		},
+		"used within the window": {
+			ops:      []int64{100, 103},
+			sinceAgo: 24 * time.Hour,
+			wantErr:  true,
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			since := time.Now().Add(-tc.sinceAgo)
+			for _, ops := range tc.ops {
+				mt.AddMockResponses(indexStatsResponse(ops, since))
+			}
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+			resp := deleteIndex(mt.T, mt, guarded)
+			if resp.Diagnostics.HasError() != tc.wantErr {
+				mt.Errorf("error = %v, want error %t", resp.Diagnostics, tc.wantErr)
+			}
+			dropped := slices.Contains(commandNames(mt), "dropIndexes")
+			if dropped == tc.wantErr {
+				mt.Errorf("commands = %v, want dropped %t", commandNames(mt), !tc.wantErr)
+			}
+		})
+	}
+
+	mt.Run("force_destroy", func(mt *mtest.T) {
+		forced := guarded
+		forced.ForceDestroy = types.BoolValue(true)
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		if resp := deleteIndex(mt.T, mt, forced); resp.Diagnostics.HasError() {
+			mt.Errorf("forced destroy was guarded: %v", resp.Diagnostics)
+		}
+		if got := commandNames(mt); !slices.Equal(got, []string{"dropIndexes"}) {
+			mt.Errorf("commands = %v, want only dropIndexes", got)
+		}
+	})
+
+	mt.Run("window longer than the timeout", func(mt *mtest.T) {
+		mt.AddMockResponses(indexStatsResponse(100, time.Now().Add(-24*time.Hour)))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		start := time.Now()
+		coll := mt.Client.Database("db").Collection("coll")
+		if _, err := recentOps(ctx, coll, "a_1", time.Hour); err == nil {
+			mt.Errorf("recentOps waited past the timeout")
+		}
+		if waited := time.Since(start); waited > 10*time.Second {
+			mt.Errorf("recentOps waited %s before failing", waited)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var _ validator.String = extJSONDocumentValidator{}
+
+// extJSONDocumentValidator validates that a string is a MongoDB Extended JSON document,
+// pointing at the offending location when the JSON is malformed.
+type extJSONDocumentValidator struct{}
+
+func (v extJSONDocumentValidator) Description(_ context.Context) string {
+	return "value must be a valid MongoDB Extended JSON document"
+}
+
+func (v extJSONDocumentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v extJSONDocumentValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+
+	var js json.RawMessage
+	if err := json.Unmarshal([]byte(value), &js); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, col := lineAndColumn(value, syntaxErr.Offset)
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Malformed JSON",
+				fmt.Sprintf("%s at line %d, column %d (byte offset %d):\n%s", syntaxErr, line, col, syntaxErr.Offset, excerpt(value, syntaxErr.Offset)),
+			)
+			return
+		}
+		resp.Diagnostics.AddAttributeError(req.Path, "Malformed JSON", err.Error())
+		return
+	}
+
+	var raw bson.Raw
+	if err := bson.UnmarshalExtJSON([]byte(value), false, &raw); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Extended JSON document", err.Error())
+	}
+}
+
+// lineAndColumn converts a json.SyntaxError offset, which counts the bytes read up to and including the
+// offending one, into the 1-based line and column of that byte.
+func lineAndColumn(s string, offset int64) (int, int) {
+	offset = min(max(offset, 1), int64(len(s)))
+	before := s[:offset-1]
+	line := strings.Count(before, "\n") + 1
+	col := len(before) - strings.LastIndex(before, "\n")
+	return line, col
+}
+
+// excerpt returns the text around offset with a marker under the offending position.
+func excerpt(s string, offset int64) string {
+	const radius = 20
+
+	start := max(int(offset)-radius, 0)
+	end := min(int(offset)+radius, len(s))
+	snippet := strings.ReplaceAll(s[start:end], "\n", " ")
+	marker := strings.Repeat(" ", max(int(offset)-start-1, 0)) + "^"
+	return snippet + "\n" + marker
+}
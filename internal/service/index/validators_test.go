@@ -0,0 +1,127 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// syntaxErrorOffset returns the offset json reports for malformed s.
+func syntaxErrorOffset(t *testing.T, s string) int64 {
+	t.Helper()
+	var js json.RawMessage
+	var syntaxErr *json.SyntaxError
+	if err := json.Unmarshal([]byte(s), &js); !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected a syntax error for %q, got %v", s, err)
+	}
+	return syntaxErr.Offset
+}
+
+func TestLineAndColumn(t *testing.T) {
+	cases := map[string]struct {
+		json      string
+		line, col int
+	}{
+		"first line":           {json: `{"a": x}`, line: 1, col: 7},
+		"first column":         {json: `x`, line: 1, col: 1},
+		"second line":          {json: "{\n  \"a\": x\n}", line: 2, col: 8},
+		"after a blank line":   {json: "{\n\n\"a\" 1}", line: 3, col: 5},
+		"unterminated at end":  {json: "{\"a\": 1", line: 1, col: 7},
+		"trailing comma":       {json: "{\"a\": 1,\n}", line: 2, col: 1},
+		"multi-byte character": {json: `{"é": x}`, line: 1, col: 8},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			line, col := lineAndColumn(tc.json, syntaxErrorOffset(t, tc.json))
+			if line != tc.line || col != tc.col {
+				t.Errorf("lineAndColumn = %d:%d, want %d:%d", line, col, tc.line, tc.col)
+			}
+		})
+	}
+}
+
+func TestLineAndColumnOutOfRange(t *testing.T) {
+	if line, col := lineAndColumn("{}", 10); line != 1 || col != 2 {
+		t.Errorf("lineAndColumn past the end = %d:%d, want 1:2", line, col)
+	}
+	if line, col := lineAndColumn("{}", 0); line != 1 || col != 1 {
+		t.Errorf("lineAndColumn at zero = %d:%d, want 1:1", line, col)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	cases := map[string]struct {
+		json string
+		want string
+	}{
+		"short document": {
+			json: `{"a": x}`,
+			want: "{\"a\": x}\n      ^",
+		},
+		"newlines flattened": {
+			json: "{\n\"a\": x}",
+			want: "{ \"a\": x}\n       ^",
+		},
+		"long document trimmed": {
+			json: `{"field_one": 1, "field_two": 2, "field_three": x, "field_four": 4, "field_five": 5}`,
+			want: " 2, \"field_three\": x, \"field_four\": 4, \"\n                   ^",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := excerpt(tc.json, syntaxErrorOffset(t, tc.json))
+			if got != tc.want {
+				t.Errorf("excerpt =\n%s\nwant\n%s", got, tc.want)
+			}
+			// The marker points at the offending byte
+			snippet, marker, _ := strings.Cut(got, "\n")
+			if snippet[len(marker)-1] != 'x' {
+				t.Errorf("marker points at %q, want 'x'", snippet[len(marker)-1])
+			}
+		})
+	}
+}
+
+func TestExtJSONDocumentValidator(t *testing.T) {
+	cases := map[string]struct {
+		value   types.String
+		summary string
+		detail  string
+	}{
+		"valid":        {value: types.StringValue(`{"a": {"$numberInt": "1"}}`)},
+		"null":         {value: types.StringNull()},
+		"unknown":      {value: types.StringUnknown()},
+		"malformed":    {value: types.StringValue("{\n  \"a\": x\n}"), summary: "Malformed JSON", detail: "at line 2, column 8 (byte offset 10)"},
+		"not document": {value: types.StringValue(`[1, 2]`), summary: "Invalid Extended JSON document"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("keys"), ConfigValue: tc.value}
+			var resp validator.StringResponse
+			extJSONDocumentValidator{}.ValidateString(context.Background(), req, &resp)
+
+			if tc.summary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got %v", resp.Diagnostics)
+			}
+			d := resp.Diagnostics.Errors()[0]
+			if d.Summary() != tc.summary || !strings.Contains(d.Detail(), tc.detail) {
+				t.Errorf("diagnostic = %q: %q, want %q containing %q", d.Summary(), d.Detail(), tc.summary, tc.detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/datafy-io/terraform-provider-mongodb/internal/importid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ resource.Resource = &Resource{}
+var _ resource.ResourceWithConfigure = &Resource{}
+var _ resource.ResourceWithImportState = &Resource{}
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client *mongo.Client
+}
+
+type privilegeResourceModel struct {
+	DB         types.String `tfsdk:"db"`
+	Collection types.String `tfsdk:"collection"`
+	Cluster    types.Bool   `tfsdk:"cluster"`
+}
+
+type privilegeModel struct {
+	Resource *privilegeResourceModel `tfsdk:"resource"`
+	Actions  []types.String          `tfsdk:"actions"`
+}
+
+type inheritedRoleModel struct {
+	Role types.String `tfsdk:"role"`
+	DB   types.String `tfsdk:"db"`
+}
+
+type ResourceModel struct {
+	ID             types.String         `tfsdk:"id"`
+	Database       types.String         `tfsdk:"database"`
+	Role           types.String         `tfsdk:"role"`
+	Privileges     []privilegeModel     `tfsdk:"privileges"`
+	InheritedRoles []inheritedRoleModel `tfsdk:"inherited_roles"`
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a MongoDB custom role.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "Database the role is defined in.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:    true,
+				Description: "Role name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"privileges": schema.SetNestedBlock{
+				Description: "Privileges granted directly by the role.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"actions": schema.SetAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Description: "Actions allowed on the resource, e.g. 'find' or 'insert'.",
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"resource": schema.SingleNestedBlock{
+							Description: "Resource the actions apply to: a database and collection, or the cluster. Required.",
+							Validators: []validator.Object{
+								objectvalidator.IsRequired(),
+							},
+							Attributes: map[string]schema.Attribute{
+								"db": schema.StringAttribute{
+									Optional:    true,
+									Description: "Database name. If not set, matches all databases.",
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+								"collection": schema.StringAttribute{
+									Optional:    true,
+									Description: "Collection name. If not set, matches all collections in db.",
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+								"cluster": schema.BoolAttribute{
+									Optional:    true,
+									Description: "Set to true to grant cluster-wide actions instead of db/collection ones.",
+								},
+							},
+						},
+					},
+				},
+			},
+			"inherited_roles": schema.SetNestedBlock{
+				Description: "Roles this role inherits privileges from.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Required:    true,
+							Description: "Role name.",
+						},
+						"db": schema.StringAttribute{
+							Required:    true,
+							Description: "Database the role is defined in.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cmd := bson.D{
+		{Key: "createRole", Value: plan.Role.ValueString()},
+		{Key: "privileges", Value: privilegesDocument(plan.Privileges)},
+		{Key: "roles", Value: inheritedRolesDocument(plan.InheritedRoles)},
+	}
+	if err := r.client.Database(plan.Database.ValueString()).RunCommand(ctx, cmd).Err(); err != nil {
+		resp.Diagnostics.AddError("createRole failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Role.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := rolesInfo(ctx, r.client.Database(state.Database.ValueString()), state.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("rolesInfo failed", err.Error())
+		return
+	}
+	if info == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Privileges = info.privilegeModels()
+	state.InheritedRoles = info.inheritedRoleModels()
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s", state.Database.ValueString(), state.Role.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// updateRole replaces both lists wholesale
+	cmd := bson.D{
+		{Key: "updateRole", Value: plan.Role.ValueString()},
+		{Key: "privileges", Value: privilegesDocument(plan.Privileges)},
+		{Key: "roles", Value: inheritedRolesDocument(plan.InheritedRoles)},
+	}
+	if err := r.client.Database(plan.Database.ValueString()).RunCommand(ctx, cmd).Err(); err != nil {
+		resp.Diagnostics.AddError("updateRole failed", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Role.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cmd := bson.D{{Key: "dropRole", Value: state.Role.ValueString()}}
+	if err := r.client.Database(state.Database.ValueString()).RunCommand(ctx, cmd).Err(); err != nil {
+		if isRoleNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("dropRole failed", err.Error())
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.TrimSpace(req.ID)
+	parts, ok := importid.Split(id, 2)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected 'database/role', with any '/' in a name encoded as %%2F, got %s", id),
+		)
+		return
+	}
+	db, role := parts[0], parts[1]
+
+	var state ResourceModel
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s", db, role))
+	state.Database = types.StringValue(db)
+	state.Role = types.StringValue(role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func privilegesDocument(privileges []privilegeModel) bson.A {
+	doc := bson.A{}
+	for _, p := range privileges {
+		privilege := bson.D{}
+		if p.Resource != nil {
+			privilege = append(privilege, bson.E{Key: "resource", Value: p.Resource.document()})
+		}
+
+		actions := bson.A{}
+		for _, a := range p.Actions {
+			actions = append(actions, a.ValueString())
+		}
+		doc = append(doc, append(privilege, bson.E{Key: "actions", Value: actions}))
+	}
+	return doc
+}
+
+// document returns the privilege resource document. MongoDB needs both db and collection on a non-cluster
+// resource and matches everything with an empty string, which is what an unset name means here.
+func (m *privilegeResourceModel) document() bson.D {
+	if m.Cluster.ValueBool() {
+		return bson.D{{Key: "cluster", Value: true}}
+	}
+	return bson.D{
+		{Key: "db", Value: m.DB.ValueString()},
+		{Key: "collection", Value: m.Collection.ValueString()},
+	}
+}
+
+func inheritedRolesDocument(roles []inheritedRoleModel) bson.A {
+	doc := bson.A{}
+	for _, role := range roles {
+		doc = append(doc, bson.D{
+			{Key: "role", Value: role.Role.ValueString()},
+			{Key: "db", Value: role.DB.ValueString()},
+		})
+	}
+	return doc
+}
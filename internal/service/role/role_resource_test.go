@@ -0,0 +1,121 @@
+package role
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestPrivilegesDocument(t *testing.T) {
+	cases := map[string]struct {
+		resource *privilegeResourceModel
+		want     bson.D
+	}{
+		"collection": {
+			resource: &privilegeResourceModel{DB: types.StringValue("app"), Collection: types.StringValue("orders"), Cluster: types.BoolNull()},
+			want:     bson.D{{Key: "db", Value: "app"}, {Key: "collection", Value: "orders"}},
+		},
+		"all collections": {
+			resource: &privilegeResourceModel{DB: types.StringValue("app"), Collection: types.StringNull(), Cluster: types.BoolNull()},
+			want:     bson.D{{Key: "db", Value: "app"}, {Key: "collection", Value: ""}},
+		},
+		"cluster": {
+			resource: &privilegeResourceModel{DB: types.StringNull(), Collection: types.StringNull(), Cluster: types.BoolValue(true)},
+			want:     bson.D{{Key: "cluster", Value: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			doc := privilegesDocument([]privilegeModel{{Resource: tc.resource, Actions: []types.String{types.StringValue("find")}}})
+			want := bson.A{bson.D{{Key: "resource", Value: tc.want}, {Key: "actions", Value: bson.A{"find"}}}}
+			if !reflect.DeepEqual(doc, want) {
+				t.Errorf("got %v, want %v", doc, want)
+			}
+		})
+	}
+
+	t.Run("omitted resource", func(t *testing.T) {
+		doc := privilegesDocument([]privilegeModel{{Actions: []types.String{types.StringValue("find")}}})
+		want := bson.A{bson.D{{Key: "actions", Value: bson.A{"find"}}}}
+		if !reflect.DeepEqual(doc, want) {
+			t.Errorf("got %v, want %v", doc, want)
+		}
+	})
+}
+
+// TestPrivilegesRoundTrip checks that privileges read back from rolesInfo equal the configured ones.
+func TestPrivilegesRoundTrip(t *testing.T) {
+	configured := []privilegeModel{
+		{
+			Resource: &privilegeResourceModel{DB: types.StringValue("app"), Collection: types.StringNull(), Cluster: types.BoolNull()},
+			Actions:  []types.String{types.StringValue("find")},
+		},
+		{
+			Resource: &privilegeResourceModel{DB: types.StringNull(), Collection: types.StringNull(), Cluster: types.BoolValue(true)},
+			Actions:  []types.String{types.StringValue("serverStatus")},
+		},
+	}
+
+	raw, err := bson.Marshal(bson.D{{Key: "role", Value: "reader"}, {Key: "db", Value: "app"}, {Key: "privileges", Value: privilegesDocument(configured)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var info roleInfo
+	if err := bson.Unmarshal(raw, &info); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := info.privilegeModels(); !reflect.DeepEqual(got, configured) {
+		t.Errorf("got %+v, want %+v", got, configured)
+	}
+}
+
+func TestImportState(t *testing.T) {
+	ctx := context.Background()
+	r := &Resource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	cases := map[string]struct {
+		id       string
+		database string
+		role     string
+		wantErr  bool
+	}{
+		"plain":          {id: "app/reader", database: "app", role: "reader"},
+		"encoded slash":  {id: "app/team%2Freader", database: "app", role: "team/reader"},
+		"missing role":   {id: "app/", wantErr: true},
+		"missing slash":  {id: "app", wantErr: true},
+		"surrounding ws": {id: " app/reader ", database: "app", role: "reader"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tc.id}, &resp)
+			if resp.Diagnostics.HasError() != tc.wantErr {
+				t.Fatalf("error = %v, want error %t", resp.Diagnostics, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			var state ResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if state.Database.ValueString() != tc.database || state.Role.ValueString() != tc.role {
+				t.Errorf("imported %s/%s, want %s/%s", state.Database.ValueString(), state.Role.ValueString(), tc.database, tc.role)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package role
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// roleNotFoundCode is the server error code returned for commands on a missing role.
+const roleNotFoundCode = 31
+
+type roleInfo struct {
+	Role       string `bson:"role"`
+	DB         string `bson:"db"`
+	Privileges []struct {
+		Resource struct {
+			DB         *string `bson:"db"`
+			Collection *string `bson:"collection"`
+			Cluster    bool    `bson:"cluster"`
+		} `bson:"resource"`
+		Actions []string `bson:"actions"`
+	} `bson:"privileges"`
+	Roles []struct {
+		Role string `bson:"role"`
+		DB   string `bson:"db"`
+	} `bson:"roles"`
+}
+
+func (ri *roleInfo) privilegeModels() []privilegeModel {
+	var privileges []privilegeModel
+	for _, p := range ri.Privileges {
+		res := &privilegeResourceModel{
+			DB:         wildcardName(p.Resource.DB),
+			Collection: wildcardName(p.Resource.Collection),
+			Cluster:    types.BoolNull(),
+		}
+		if p.Resource.Cluster {
+			res.Cluster = types.BoolValue(true)
+		}
+
+		actions := make([]types.String, 0, len(p.Actions))
+		for _, a := range p.Actions {
+			actions = append(actions, types.StringValue(a))
+		}
+		privileges = append(privileges, privilegeModel{Resource: res, Actions: actions})
+	}
+	return privileges
+}
+
+// wildcardName reads a privilege resource name, where an empty string matches every name. That is the
+// unset attribute in configuration.
+func wildcardName(name *string) types.String {
+	if name == nil || *name == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*name)
+}
+
+func (ri *roleInfo) inheritedRoleModels() []inheritedRoleModel {
+	var roles []inheritedRoleModel
+	for _, role := range ri.Roles {
+		roles = append(roles, inheritedRoleModel{
+			Role: types.StringValue(role.Role),
+			DB:   types.StringValue(role.DB),
+		})
+	}
+	return roles
+}
+
+// rolesInfo looks up a single role with its direct privileges, returning nil when the role does not exist.
+func rolesInfo(ctx context.Context, db *mongo.Database, role string) (*roleInfo, error) {
+	var result struct {
+		Roles []roleInfo `bson:"roles"`
+	}
+	cmd := bson.D{
+		{Key: "rolesInfo", Value: bson.D{
+			{Key: "role", Value: role},
+			{Key: "db", Value: db.Name()},
+		}},
+		{Key: "showPrivileges", Value: true},
+	}
+	if err := db.RunCommand(ctx, cmd).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Roles) == 0 {
+		return nil, nil
+	}
+	return &result.Roles[0], nil
+}
+
+func isRoleNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == roleNotFoundCode || cmdErr.Name == "RoleNotFound"
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func newMockTest(t *testing.T) *mtest.T {
+	return mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+}
+
+// newState returns user resource state holding model, or a null state when model is nil.
+func newState(t *testing.T, model *ResourceModel) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&Resource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if model != nil {
+		if diags := state.Set(ctx, model); diags.HasError() {
+			t.Fatalf("set state: %v", diags)
+		}
+	}
+	return state
+}
+
+// updateUser runs the resource Update from state to plan and returns any diagnostics as an error string.
+func updateUser(t *testing.T, client *mongo.Client, state, plan ResourceModel) string {
+	t.Helper()
+	planState := newState(t, &plan)
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw},
+		State: newState(t, &state),
+	}
+	resp := resource.UpdateResponse{State: req.State}
+	(&Resource{client: client}).Update(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		return resp.Diagnostics[0].Summary() + ": " + resp.Diagnostics[0].Detail()
+	}
+	return ""
+}
+
+// createUser runs the resource Create for plan. It returns the new state and any diagnostics as an error string.
+func createUser(t *testing.T, client *mongo.Client, plan ResourceModel) (ResourceModel, string) {
+	t.Helper()
+	planState := newState(t, &plan)
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: planState.Schema, Raw: planState.Raw}}
+	resp := resource.CreateResponse{State: newState(t, nil)}
+	(&Resource{client: client}).Create(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		return ResourceModel{}, resp.Diagnostics[0].Summary() + ": " + resp.Diagnostics[0].Detail()
+	}
+
+	var model ResourceModel
+	if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+	return model, ""
+}
+
+// readUser runs the resource Read against client with prior as the stored state and returns the new state.
+func readUser(t *testing.T, client *mongo.Client, prior ResourceModel) ResourceModel {
+	t.Helper()
+	state := newState(t, &prior)
+	resp := resource.ReadResponse{State: state}
+	(&Resource{client: client}).Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read: %v", resp.Diagnostics)
+	}
+
+	var model ResourceModel
+	if diags := resp.State.Get(context.Background(), &model); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+	return model
+}
+
+// commandNames returns the names of the commands started against the mocked server, in order.
+func commandNames(mt *mtest.T) []string {
+	var names []string
+	for _, e := range mt.GetAllStartedEvents() {
+		names = append(names, e.CommandName)
+	}
+	return names
+}
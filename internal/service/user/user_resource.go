@@ -0,0 +1,519 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var _ resource.Resource = &Resource{}
+var _ resource.ResourceWithConfigure = &Resource{}
+var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
+
+// SCRAM mechanisms a user's credentials can be created for.
+const (
+	scramSHA1   = "SCRAM-SHA-1"
+	scramSHA256 = "SCRAM-SHA-256"
+)
+
+// externalDatabase is the virtual database of users authenticated outside MongoDB (x509, LDAP, Kerberos),
+// which have no password.
+const externalDatabase = "$external"
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client *mongo.Client
+}
+
+type roleModel struct {
+	Role types.String `tfsdk:"role"`
+	DB   types.String `tfsdk:"db"`
+}
+
+type authenticationRestrictionModel struct {
+	ClientSource  []types.String `tfsdk:"client_source"`
+	ServerAddress []types.String `tfsdk:"server_address"`
+}
+
+type ResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Database types.String `tfsdk:"database"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Roles    []roleModel  `tfsdk:"roles"`
+
+	Mechanisms types.Set `tfsdk:"mechanisms"`
+
+	AllowPrivileged types.Bool `tfsdk:"allow_privileged"`
+
+	AuthenticationRestrictions []authenticationRestrictionModel `tfsdk:"authentication_restrictions"`
+}
+
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a MongoDB database user.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Required:    true,
+				Description: "Authentication database the user is created in.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Required:    true,
+				Description: "User name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "User password. Required unless database is '$external', whose users (x509, LDAP, Kerberos) have none. MongoDB can't return it, so changes made outside Terraform are not detected, and an imported user's password is set again from configuration on the next apply.",
+			},
+			"allow_privileged": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Must be true to grant or revoke administrative roles such as root, userAdminAnyDatabase or clusterAdmin, which can lock administrators out when changed by mistake. (Default: false)",
+			},
+			"mechanisms": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Description: "SCRAM mechanisms to create credentials for: 'SCRAM-SHA-1' and/or 'SCRAM-SHA-256'. Defaults to those enabled on the server. Adding a mechanism needs password to be set.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(scramSHA1, scramSHA256)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"roles": schema.SetNestedBlock{
+				Description: "Roles granted to the user.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Required:    true,
+							Description: "Role name, e.g. 'readWrite'.",
+						},
+						"db": schema.StringAttribute{
+							Required:    true,
+							Description: "Database the role applies to.",
+						},
+					},
+				},
+			},
+			"authentication_restrictions": schema.ListNestedBlock{
+				Description: "Where the user may authenticate from. The user may authenticate if any one restriction is met.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"client_source": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "IP addresses or CIDR ranges the client must connect from.",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(addressValidator{}),
+							},
+						},
+						"server_address": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "IP addresses or CIDR ranges of the server addresses the client must connect to.",
+							Validators: []validator.Set{
+								setvalidator.ValueStringsAre(addressValidator{}),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config ResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Database.IsUnknown() || config.Password.IsUnknown() {
+		return
+	}
+
+	external := config.Database.ValueString() == externalDatabase
+	if external && !config.Mechanisms.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("mechanisms"),
+			"Mechanisms on an external user",
+			"Users in the '$external' database have no SCRAM credentials, so mechanisms can't be set.",
+		)
+	}
+	if external && !config.Password.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Password on an external user",
+			"Users in the '$external' database authenticate outside MongoDB and can't have a password.",
+		)
+	}
+	if !external && config.Password.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing password",
+			"password is required unless database is '$external'.",
+		)
+	}
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if roles := privilegedRoles(plan.Roles); len(roles) > 0 && !plan.AllowPrivileged.ValueBool() {
+		resp.Diagnostics.AddError("Privileged roles not allowed", privilegedRolesDetail(roles))
+		return
+	}
+
+	db := r.client.Database(plan.Database.ValueString())
+	if err := db.RunCommand(ctx, createUserCommand(plan)).Err(); err != nil {
+		resp.Diagnostics.AddError("createUser failed", err.Error())
+		return
+	}
+
+	if plan.Mechanisms.IsUnknown() {
+		// The server picked the mechanisms
+		info, err := usersInfo(ctx, db, plan.Username.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("usersInfo failed", err.Error())
+			return
+		}
+		if info == nil {
+			resp.Diagnostics.AddError("usersInfo failed", fmt.Sprintf("User %s was not found after it was created.", plan.Username.ValueString()))
+			return
+		}
+		var diags diag.Diagnostics
+		plan.Mechanisms, diags = types.SetValueFrom(ctx, types.StringType, info.Mechanisms)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Username.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := usersInfo(ctx, r.client.Database(state.Database.ValueString()), state.Username.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("usersInfo failed", err.Error())
+		return
+	}
+	if info == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The password can't be read back; keep whatever is in state
+	state.Roles = info.roleModels()
+	var diags diag.Diagnostics
+	state.Mechanisms, diags = types.SetValueFrom(ctx, types.StringType, info.Mechanisms)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.AuthenticationRestrictions = info.restrictionModels()
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s", state.Database.ValueString(), state.Username.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ResourceModel
+	var state ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	db := r.client.Database(plan.Database.ValueString())
+
+	// Roles are granted before any are revoked, so a user being moved between roles never loses access in between
+	granted, revoked := diffRoles(state.Roles, plan.Roles)
+	if roles := privilegedRoles(slices.Concat(granted, revoked)); len(roles) > 0 && !plan.AllowPrivileged.ValueBool() {
+		resp.Diagnostics.AddError("Privileged roles not allowed", privilegedRolesDetail(roles))
+		return
+	}
+	if len(granted) > 0 {
+		cmd := bson.D{
+			{Key: "grantRolesToUser", Value: plan.Username.ValueString()},
+			{Key: "roles", Value: rolesDocument(granted)},
+		}
+		if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+			resp.Diagnostics.AddError("grantRolesToUser failed", err.Error())
+			return
+		}
+	}
+	if len(revoked) > 0 {
+		cmd := bson.D{
+			{Key: "revokeRolesFromUser", Value: plan.Username.ValueString()},
+			{Key: "roles", Value: rolesDocument(revoked)},
+		}
+		if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+			resp.Diagnostics.AddError("revokeRolesFromUser failed", err.Error())
+			return
+		}
+	}
+
+	if cmd := updateUserCommand(plan, state); len(cmd) > 1 {
+		if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+			resp.Diagnostics.AddError("updateUser failed", err.Error())
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.Database.ValueString(), plan.Username.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cmd := bson.D{{Key: "dropUser", Value: state.Username.ValueString()}}
+	if err := r.client.Database(state.Database.ValueString()).RunCommand(ctx, cmd).Err(); err != nil {
+		if isUserNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("dropUser failed", err.Error())
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := strings.TrimSpace(req.ID)
+	db, username, ok := parseImportID(id)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected 'database/username' or 'database.username', got %s", id),
+		)
+		return
+	}
+
+	// The password can't be imported; the next apply sets it from configuration
+	var state ResourceModel
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s", db, username))
+	state.Database = types.StringValue(db)
+	state.Username = types.StringValue(username)
+	state.Password = types.StringNull()
+	state.Mechanisms = types.SetNull(types.StringType)
+	state.AllowPrivileged = types.BoolValue(false)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// parseImportID splits 'database/username' or 'database.username'. Database names can't contain
+// '/' or '.', so the first separator always ends the database name.
+func parseImportID(id string) (db, username string, ok bool) {
+	i := strings.IndexAny(id, "/.")
+	if i <= 0 || i == len(id)-1 {
+		return "", "", false
+	}
+	return id[:i], id[i+1:], true
+}
+
+// createUserCommand returns the createUser command for plan.
+func createUserCommand(plan ResourceModel) bson.D {
+	cmd := bson.D{{Key: "createUser", Value: plan.Username.ValueString()}}
+	if !plan.Password.IsNull() {
+		cmd = append(cmd, bson.E{Key: "pwd", Value: plan.Password.ValueString()})
+	}
+	cmd = append(cmd, bson.E{Key: "roles", Value: rolesDocument(plan.Roles)})
+	if mechanisms := setStrings(plan.Mechanisms); len(mechanisms) > 0 {
+		cmd = append(cmd, bson.E{Key: "mechanisms", Value: mechanisms})
+	}
+	if len(plan.AuthenticationRestrictions) > 0 {
+		cmd = append(cmd, bson.E{Key: "authenticationRestrictions", Value: restrictionsDocument(plan.AuthenticationRestrictions)})
+	}
+	return cmd
+}
+
+// updateUserCommand returns the updateUser command moving a user from state to plan. Roles are changed
+// separately, so it holds nothing but the user name when only roles changed.
+func updateUserCommand(plan, state ResourceModel) bson.D {
+	cmd := bson.D{{Key: "updateUser", Value: plan.Username.ValueString()}}
+	mechanisms := setStrings(plan.Mechanisms)
+	changeMechanisms := len(mechanisms) > 0 && !slices.Equal(mechanisms, setStrings(state.Mechanisms))
+	// A password can be changed but not removed. Credentials for added mechanisms are derived from it,
+	// so it is sent again along with a mechanism change.
+	if !plan.Password.IsNull() && (!plan.Password.Equal(state.Password) || changeMechanisms) {
+		cmd = append(cmd, bson.E{Key: "pwd", Value: plan.Password.ValueString()})
+	}
+	if changeMechanisms {
+		cmd = append(cmd, bson.E{Key: "mechanisms", Value: mechanisms})
+	}
+	// updateUser replaces the whole list; an empty one removes all restrictions
+	if !reflect.DeepEqual(restrictionsDocument(plan.AuthenticationRestrictions), restrictionsDocument(state.AuthenticationRestrictions)) {
+		cmd = append(cmd, bson.E{Key: "authenticationRestrictions", Value: restrictionsDocument(plan.AuthenticationRestrictions)})
+	}
+	return cmd
+}
+
+// setStrings returns the known elements of a string set, sorted.
+func setStrings(set types.Set) []string {
+	var out []string
+	for _, v := range set.Elements() {
+		if s, ok := v.(types.String); ok && !s.IsUnknown() {
+			out = append(out, s.ValueString())
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func rolesDocument(roles []roleModel) bson.A {
+	doc := bson.A{}
+	for _, role := range roles {
+		doc = append(doc, bson.D{
+			{Key: "role", Value: role.Role.ValueString()},
+			{Key: "db", Value: role.DB.ValueString()},
+		})
+	}
+	return doc
+}
+
+func restrictionsDocument(restrictions []authenticationRestrictionModel) bson.A {
+	// Sets carry no order; sorting keeps the documents comparable
+	strs := func(values []types.String) []string {
+		out := make([]string, 0, len(values))
+		for _, v := range values {
+			out = append(out, v.ValueString())
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	doc := bson.A{}
+	for _, r := range restrictions {
+		restriction := bson.D{}
+		if len(r.ClientSource) > 0 {
+			restriction = append(restriction, bson.E{Key: "clientSource", Value: strs(r.ClientSource)})
+		}
+		if len(r.ServerAddress) > 0 {
+			restriction = append(restriction, bson.E{Key: "serverAddress", Value: strs(r.ServerAddress)})
+		}
+		doc = append(doc, restriction)
+	}
+	return doc
+}
+
+// adminAnyDatabaseRoles are the built-in roles that administer the deployment regardless of their database.
+var adminAnyDatabaseRoles = map[string]bool{
+	"root":                 true,
+	"__system":             true,
+	"userAdminAnyDatabase": true,
+	"clusterAdmin":         true,
+	"restore":              true,
+}
+
+// privilegedRoles returns the roles that give control over users or the whole deployment.
+func privilegedRoles(roles []roleModel) []string {
+	var privileged []string
+	for _, r := range roles {
+		name, db := r.Role.ValueString(), r.DB.ValueString()
+		// User administration on admin covers every user, including its own grants
+		if adminAnyDatabaseRoles[name] || (db == "admin" && (name == "userAdmin" || name == "dbOwner")) {
+			privileged = append(privileged, db+"."+name)
+		}
+	}
+	return privileged
+}
+
+func privilegedRolesDetail(roles []string) string {
+	return fmt.Sprintf("Granting or revoking %s can lock administrators out of the deployment. Set allow_privileged = true to proceed.", strings.Join(roles, ", "))
+}
+
+// diffRoles returns the roles in to but not in from, and the roles in from but not in to.
+func diffRoles(from, to []roleModel) (added, removed []roleModel) {
+	key := func(r roleModel) string { return r.DB.ValueString() + "." + r.Role.ValueString() }
+	inFrom := make(map[string]bool, len(from))
+	for _, r := range from {
+		inFrom[key(r)] = true
+	}
+	inTo := make(map[string]bool, len(to))
+	for _, r := range to {
+		inTo[key(r)] = true
+		if !inFrom[key(r)] {
+			added = append(added, r)
+		}
+	}
+	for _, r := range from {
+		if !inTo[key(r)] {
+			removed = append(removed, r)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,338 @@
+package user
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func role(name, db string) roleModel {
+	return roleModel{Role: types.StringValue(name), DB: types.StringValue(db)}
+}
+
+func userModel(roles ...roleModel) ResourceModel {
+	return ResourceModel{
+		ID:       types.StringValue("app/alice"),
+		Database: types.StringValue("app"),
+		Username: types.StringValue("alice"),
+		Password: types.StringValue("secret"),
+		Roles:    roles,
+
+		Mechanisms:      types.SetNull(types.StringType),
+		AllowPrivileged: types.BoolValue(false),
+	}
+}
+
+func TestUpdateDiffsRoles(t *testing.T) {
+	cases := map[string]struct {
+		state    []roleModel
+		plan     []roleModel
+		commands []string
+		granted  []string
+		revoked  []string
+	}{
+		"role added": {
+			state:    []roleModel{role("read", "app")},
+			plan:     []roleModel{role("read", "app"), role("readWrite", "logs")},
+			commands: []string{"grantRolesToUser"},
+			granted:  []string{"logs.readWrite"},
+		},
+		"role removed": {
+			state:    []roleModel{role("read", "app"), role("readWrite", "logs")},
+			plan:     []roleModel{role("read", "app")},
+			commands: []string{"revokeRolesFromUser"},
+			revoked:  []string{"logs.readWrite"},
+		},
+		"role replaced": {
+			state:    []roleModel{role("read", "app")},
+			plan:     []roleModel{role("readWrite", "app")},
+			commands: []string{"grantRolesToUser", "revokeRolesFromUser"},
+			granted:  []string{"app.readWrite"},
+			revoked:  []string{"app.read"},
+		},
+		"roles reordered": {
+			state: []roleModel{role("read", "app"), role("read", "logs")},
+			plan:  []roleModel{role("read", "logs"), role("read", "app")},
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			for range tc.commands {
+				mt.AddMockResponses(mtest.CreateSuccessResponse())
+			}
+			if err := updateUser(mt.T, mt.Client, userModel(tc.state...), userModel(tc.plan...)); err != "" {
+				mt.Fatal(err)
+			}
+
+			if got := commandNames(mt); !slices.Equal(got, tc.commands) {
+				mt.Fatalf("commands = %v, want %v", got, tc.commands)
+			}
+			for _, e := range mt.GetAllStartedEvents() {
+				var cmd struct {
+					Roles []struct {
+						Role string `bson:"role"`
+						DB   string `bson:"db"`
+					} `bson:"roles"`
+				}
+				if err := bson.Unmarshal(e.Command, &cmd); err != nil {
+					mt.Fatal(err)
+				}
+				var roles []string
+				for _, r := range cmd.Roles {
+					roles = append(roles, r.DB+"."+r.Role)
+				}
+				want := tc.granted
+				if e.CommandName == "revokeRolesFromUser" {
+					want = tc.revoked
+				}
+				if !slices.Equal(roles, want) {
+					mt.Errorf("%s roles = %v, want %v", e.CommandName, roles, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthenticationRestrictions(t *testing.T) {
+	restricted := userModel(role("read", "app"))
+	restricted.AuthenticationRestrictions = []authenticationRestrictionModel{{
+		ClientSource:  []types.String{types.StringValue("192.168.1.10"), types.StringValue("10.0.0.0/24")},
+		ServerAddress: []types.String{types.StringValue("10.0.1.5")},
+	}}
+	restrictionsDoc := bson.A{bson.D{
+		{Key: "clientSource", Value: []string{"10.0.0.0/24", "192.168.1.10"}},
+		{Key: "serverAddress", Value: []string{"10.0.1.5"}},
+	}}
+
+	t.Run("create", func(t *testing.T) {
+		got := createUserCommand(restricted)
+		want := bson.D{
+			{Key: "createUser", Value: "alice"},
+			{Key: "pwd", Value: "secret"},
+			{Key: "roles", Value: bson.A{bson.D{{Key: "role", Value: "read"}, {Key: "db", Value: "app"}}}},
+			{Key: "authenticationRestrictions", Value: restrictionsDoc},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("createUser = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		reordered := restricted
+		reordered.AuthenticationRestrictions = []authenticationRestrictionModel{{
+			ClientSource:  []types.String{types.StringValue("10.0.0.0/24"), types.StringValue("192.168.1.10")},
+			ServerAddress: []types.String{types.StringValue("10.0.1.5")},
+		}}
+		if got := updateUserCommand(reordered, restricted); len(got) != 1 {
+			t.Errorf("updateUser = %v, want no changes", got)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		got := updateUserCommand(userModel(role("read", "app")), restricted)
+		want := bson.D{
+			{Key: "updateUser", Value: "alice"},
+			{Key: "authenticationRestrictions", Value: bson.A{}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("updateUser = %v, want %v", got, want)
+		}
+	})
+
+	mt := newMockTest(t)
+	mt.Run("read", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "users", Value: bson.A{bson.D{
+			{Key: "user", Value: "alice"},
+			{Key: "db", Value: "app"},
+			{Key: "roles", Value: bson.A{bson.D{{Key: "role", Value: "read"}, {Key: "db", Value: "app"}}}},
+			{Key: "authenticationRestrictions", Value: restrictionsDoc},
+		}}}))
+		got := readUser(mt.T, mt.Client, userModel(role("read", "app")))
+
+		want := []authenticationRestrictionModel{{
+			ClientSource:  []types.String{types.StringValue("10.0.0.0/24"), types.StringValue("192.168.1.10")},
+			ServerAddress: []types.String{types.StringValue("10.0.1.5")},
+		}}
+		if !reflect.DeepEqual(got.AuthenticationRestrictions, want) {
+			mt.Errorf("authentication_restrictions = %+v, want %+v", got.AuthenticationRestrictions, want)
+		}
+	})
+}
+
+func mechanisms(values ...string) types.Set {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+// commandValue returns the value of key in cmd, or nil when absent.
+func commandValue(cmd bson.D, key string) any {
+	for _, e := range cmd {
+		if e.Key == key {
+			return e.Value
+		}
+	}
+	return nil
+}
+
+func TestMechanisms(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		plan := userModel(role("read", "app"))
+		plan.Mechanisms = mechanisms(scramSHA256, scramSHA1)
+		got := commandValue(createUserCommand(plan), "mechanisms")
+		if want := []string{scramSHA1, scramSHA256}; !reflect.DeepEqual(got, want) {
+			t.Errorf("mechanisms = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("create with server defaults", func(t *testing.T) {
+		if got := commandValue(createUserCommand(userModel()), "mechanisms"); got != nil {
+			t.Errorf("mechanisms = %v, want none", got)
+		}
+	})
+
+	t.Run("change sends the password", func(t *testing.T) {
+		state := userModel()
+		state.Mechanisms = mechanisms(scramSHA1)
+		plan := userModel()
+		plan.Mechanisms = mechanisms(scramSHA1, scramSHA256)
+
+		got := updateUserCommand(plan, state)
+		want := bson.D{
+			{Key: "updateUser", Value: "alice"},
+			{Key: "pwd", Value: "secret"},
+			{Key: "mechanisms", Value: []string{scramSHA1, scramSHA256}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("updateUser = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		state := userModel()
+		state.Mechanisms = mechanisms(scramSHA256, scramSHA1)
+		plan := userModel()
+		plan.Mechanisms = mechanisms(scramSHA1, scramSHA256)
+		if got := updateUserCommand(plan, state); len(got) != 1 {
+			t.Errorf("updateUser = %v, want no changes", got)
+		}
+	})
+
+	mt := newMockTest(t)
+	mt.Run("create reads back server defaults", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateSuccessResponse(bson.E{Key: "users", Value: bson.A{bson.D{
+				{Key: "user", Value: "alice"},
+				{Key: "db", Value: "app"},
+				{Key: "roles", Value: bson.A{}},
+				{Key: "mechanisms", Value: bson.A{scramSHA1, scramSHA256}},
+			}}}),
+		)
+		plan := userModel()
+		plan.Mechanisms = types.SetUnknown(types.StringType)
+		got, err := createUser(mt.T, mt.Client, plan)
+		if err != "" {
+			mt.Fatal(err)
+		}
+		if want := mechanisms(scramSHA1, scramSHA256); !got.Mechanisms.Equal(want) {
+			mt.Errorf("mechanisms = %s, want %s", got.Mechanisms, want)
+		}
+	})
+}
+
+func TestAllowPrivileged(t *testing.T) {
+	cases := map[string]struct {
+		state   []roleModel
+		plan    []roleModel
+		allowed bool
+		wantErr bool
+	}{
+		"grant root": {
+			plan:    []roleModel{role("root", "admin")},
+			wantErr: true,
+		},
+		"grant root when allowed": {
+			plan:    []roleModel{role("root", "admin")},
+			allowed: true,
+		},
+		"revoke userAdminAnyDatabase": {
+			state:   []roleModel{role("userAdminAnyDatabase", "admin"), role("read", "app")},
+			plan:    []roleModel{role("read", "app")},
+			wantErr: true,
+		},
+		"userAdmin on admin": {
+			plan:    []roleModel{role("userAdmin", "admin")},
+			wantErr: true,
+		},
+		"userAdmin on an application database": {
+			plan: []roleModel{role("userAdmin", "app")},
+		},
+		"unrelated change on a privileged user": {
+			state: []roleModel{role("root", "admin")},
+			plan:  []roleModel{role("root", "admin"), role("read", "app")},
+		},
+	}
+
+	mt := newMockTest(t)
+	for name, tc := range cases {
+		mt.Run(name, func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+			plan := userModel(tc.plan...)
+			plan.AllowPrivileged = types.BoolValue(tc.allowed)
+			err := updateUser(mt.T, mt.Client, userModel(tc.state...), plan)
+			if (err != "") != tc.wantErr {
+				mt.Fatalf("error = %q, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr && len(mt.GetAllStartedEvents()) > 0 {
+				mt.Errorf("commands ran despite the guard: %v", commandNames(mt))
+			}
+		})
+	}
+
+	mt.Run("create with root", func(mt *mtest.T) {
+		if _, err := createUser(mt.T, mt.Client, userModel(role("root", "admin"))); err == "" {
+			mt.Error("createUser granted root without allow_privileged")
+		}
+	})
+}
+
+func TestParseImportID(t *testing.T) {
+	cases := map[string]struct {
+		id       string
+		db, user string
+		ok       bool
+	}{
+		"slash":                   {id: "app/alice", db: "app", user: "alice", ok: true},
+		"dot":                     {id: "app.alice", db: "app", user: "alice", ok: true},
+		"username with dot":       {id: "app/alice.smith", db: "app", user: "alice.smith", ok: true},
+		"username with slash":     {id: "app.team/alice", db: "app", user: "team/alice", ok: true},
+		"username with at":        {id: "$external/alice@EXAMPLE.COM", db: "$external", user: "alice@EXAMPLE.COM", ok: true},
+		"no separator":            {id: "alice", ok: false},
+		"empty database":          {id: "/alice", ok: false},
+		"empty username":          {id: "app/", ok: false},
+		"empty username with dot": {id: "app.", ok: false},
+		"empty":                   {id: "", ok: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, user, ok := parseImportID(tc.id)
+			if ok != tc.ok {
+				t.Fatalf("parseImportID(%q) ok = %t, want %t", tc.id, ok, tc.ok)
+			}
+			if db != tc.db || user != tc.user {
+				t.Errorf("parseImportID(%q) = %q, %q, want %q, %q", tc.id, db, user, tc.db, tc.user)
+			}
+		})
+	}
+}
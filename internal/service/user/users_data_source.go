@@ -0,0 +1,189 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ListDataSource{}
+var _ datasource.DataSourceWithConfigure = &ListDataSource{}
+
+func NewListDataSource() datasource.DataSource {
+	return &ListDataSource{}
+}
+
+type ListDataSource struct {
+	client *mongo.Client
+}
+
+type privilegeResourceModel struct {
+	DB         types.String `tfsdk:"db"`
+	Collection types.String `tfsdk:"collection"`
+	Cluster    types.Bool   `tfsdk:"cluster"`
+}
+
+type inheritedPrivilegeModel struct {
+	Resource privilegeResourceModel `tfsdk:"resource"`
+	Actions  []types.String         `tfsdk:"actions"`
+}
+
+type userEntryModel struct {
+	Username            types.String              `tfsdk:"username"`
+	DB                  types.String              `tfsdk:"db"`
+	Roles               []roleModel               `tfsdk:"roles"`
+	InheritedPrivileges []inheritedPrivilegeModel `tfsdk:"inherited_privileges"`
+}
+
+type ListDataSourceModel struct {
+	ID       types.String     `tfsdk:"id"`
+	Database types.String     `tfsdk:"database"`
+	Users    []userEntryModel `tfsdk:"users"`
+}
+
+func (d *ListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *ListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists MongoDB users, their roles and inherited privileges from usersInfo. Credentials are never returned by the server.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"database": schema.StringAttribute{
+				Optional:    true,
+				Description: "Authentication database to list users from. If not set, users of all databases are listed.",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Users, sorted by database and username.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "Username.",
+						},
+						"db": schema.StringAttribute{
+							Computed:    true,
+							Description: "Authentication database of the user.",
+						},
+						"roles": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Roles granted to the user.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"role": schema.StringAttribute{
+										Computed:    true,
+										Description: "Role name.",
+									},
+									"db": schema.StringAttribute{
+										Computed:    true,
+										Description: "Database the role is defined in.",
+									},
+								},
+							},
+						},
+						"inherited_privileges": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Privileges the user effectively has through its roles, as reported by usersInfo with showPrivileges.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"resource": schema.SingleNestedAttribute{
+										Computed:    true,
+										Description: "Resource the actions apply to. db and collection are null when they match all names.",
+										Attributes: map[string]schema.Attribute{
+											"db": schema.StringAttribute{
+												Computed:    true,
+												Description: "Database name.",
+											},
+											"collection": schema.StringAttribute{
+												Computed:    true,
+												Description: "Collection name.",
+											},
+											"cluster": schema.BoolAttribute{
+												Computed:    true,
+												Description: "True for cluster-wide actions.",
+											},
+										},
+									},
+									"actions": schema.ListAttribute{
+										ElementType: types.StringType,
+										Computed:    true,
+										Description: "Actions allowed on the resource.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ListDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mongo.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected *mongo.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan ListDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := listUsers(ctx, d.client, plan.Database.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list users", err.Error())
+		return
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].DB != users[j].DB {
+			return users[i].DB < users[j].DB
+		}
+		return users[i].User < users[j].User
+	})
+
+	plan.Users = make([]userEntryModel, 0, len(users))
+	for _, u := range users {
+		roles := u.roleModels()
+		if roles == nil {
+			roles = []roleModel{}
+		}
+		plan.Users = append(plan.Users, userEntryModel{
+			Username: types.StringValue(u.User),
+			DB:       types.StringValue(u.DB),
+			Roles:    roles,
+
+			InheritedPrivileges: u.inheritedPrivilegeModels(),
+		})
+	}
+
+	plan.ID = types.StringValue(plan.Database.ValueString())
+	if plan.Database.IsNull() {
+		plan.ID = types.StringValue("*")
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
@@ -0,0 +1,114 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestListInheritedPrivileges(t *testing.T) {
+	mt := newMockTest(t)
+
+	mt.Run("privileges are looked up by name", func(mt *mtest.T) {
+		roles := bson.A{bson.D{{Key: "role", Value: "reader"}, {Key: "db", Value: "app"}}}
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "users", Value: bson.A{
+				bson.D{{Key: "user", Value: "bob"}, {Key: "db", Value: "app"}, {Key: "roles", Value: roles}},
+				bson.D{{Key: "user", Value: "alice"}, {Key: "db", Value: "app"}, {Key: "roles", Value: roles}},
+			}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "users", Value: bson.A{
+				bson.D{{Key: "user", Value: "bob"}, {Key: "db", Value: "app"}, {Key: "roles", Value: roles},
+					{Key: "inheritedPrivileges", Value: bson.A{
+						bson.D{
+							{Key: "resource", Value: bson.D{{Key: "db", Value: "app"}, {Key: "collection", Value: ""}}},
+							{Key: "actions", Value: bson.A{"find", "listCollections"}},
+						},
+						bson.D{
+							{Key: "resource", Value: bson.D{{Key: "cluster", Value: true}}},
+							{Key: "actions", Value: bson.A{"listDatabases"}},
+						},
+					}}},
+				bson.D{{Key: "user", Value: "alice"}, {Key: "db", Value: "app"}, {Key: "roles", Value: roles},
+					{Key: "inheritedPrivileges", Value: bson.A{}}},
+			}}),
+		)
+
+		model := readUsers(mt.T, mt, types.StringValue("app"))
+
+		var cmd bson.Raw
+		for _, e := range mt.GetAllStartedEvents() {
+			cmd = e.Command
+		}
+		if show, ok := cmd.Lookup("showPrivileges").BooleanOK(); !ok || !show {
+			mt.Fatalf("showPrivileges not set on %s", cmd)
+		}
+		names, _ := cmd.Lookup("usersInfo").Array().Values()
+		if len(names) != 2 || names[0].Document().Lookup("user").StringValue() != "bob" {
+			mt.Fatalf("usersInfo = %s, want both listed users", cmd.Lookup("usersInfo"))
+		}
+
+		if len(model.Users) != 2 || model.Users[0].Username.ValueString() != "alice" {
+			mt.Fatalf("users = %+v, want alice then bob", model.Users)
+		}
+		if got := model.Users[0].InheritedPrivileges; len(got) != 0 {
+			mt.Errorf("alice privileges = %+v, want none", got)
+		}
+		got := model.Users[1].InheritedPrivileges
+		if len(got) != 2 {
+			mt.Fatalf("bob privileges = %+v, want 2", got)
+		}
+		if r := got[0].Resource; r.DB.ValueString() != "app" || !r.Collection.IsNull() || r.Cluster.ValueBool() {
+			mt.Errorf("first resource = %+v, want db app and every collection", r)
+		}
+		if len(got[0].Actions) != 2 || got[0].Actions[1].ValueString() != "listCollections" {
+			mt.Errorf("first actions = %v", got[0].Actions)
+		}
+		if r := got[1].Resource; !r.Cluster.ValueBool() || !r.DB.IsNull() {
+			mt.Errorf("second resource = %+v, want cluster", r)
+		}
+	})
+
+	mt.Run("no users skips the lookup", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "users", Value: bson.A{}}))
+
+		model := readUsers(mt.T, mt, types.StringNull())
+		if len(model.Users) != 0 {
+			mt.Errorf("users = %+v, want none", model.Users)
+		}
+		if got := commandNames(mt); len(got) != 1 {
+			mt.Errorf("commands = %v, want a single usersInfo", got)
+		}
+	})
+}
+
+// readUsers runs the mongodb_users data source Read for database and returns the resulting state.
+func readUsers(t *testing.T, mt *mtest.T, database types.String) ListDataSourceModel {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	(&ListDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	config := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if diags := config.Set(ctx, &ListDataSourceModel{ID: types.StringNull(), Database: database}); diags.HasError() {
+		t.Fatalf("set config: %v", diags)
+	}
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: config.Raw}}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: config.Raw}}
+	(&ListDataSource{client: mt.Client}).Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read: %v", resp.Diagnostics)
+	}
+
+	var model ListDataSourceModel
+	if diags := resp.State.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("get state: %v", diags)
+	}
+	return model
+}
@@ -0,0 +1,155 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// userNotFoundCode is the server error code returned for commands on a missing user.
+const userNotFoundCode = 11
+
+type userInfo struct {
+	User  string `bson:"user"`
+	DB    string `bson:"db"`
+	Roles []struct {
+		Role string `bson:"role"`
+		DB   string `bson:"db"`
+	} `bson:"roles"`
+	Mechanisms                 []string `bson:"mechanisms"`
+	AuthenticationRestrictions []struct {
+		ClientSource  []string `bson:"clientSource"`
+		ServerAddress []string `bson:"serverAddress"`
+	} `bson:"authenticationRestrictions"`
+	InheritedPrivileges []struct {
+		Resource struct {
+			DB         *string `bson:"db"`
+			Collection *string `bson:"collection"`
+			Cluster    bool    `bson:"cluster"`
+		} `bson:"resource"`
+		Actions []string `bson:"actions"`
+	} `bson:"inheritedPrivileges"`
+}
+
+func (u *userInfo) roleModels() []roleModel {
+	var roles []roleModel
+	for _, role := range u.Roles {
+		roles = append(roles, roleModel{
+			Role: types.StringValue(role.Role),
+			DB:   types.StringValue(role.DB),
+		})
+	}
+	return roles
+}
+
+func (u *userInfo) restrictionModels() []authenticationRestrictionModel {
+	strs := func(values []string) []types.String {
+		var out []types.String
+		for _, v := range values {
+			out = append(out, types.StringValue(v))
+		}
+		return out
+	}
+
+	var restrictions []authenticationRestrictionModel
+	for _, r := range u.AuthenticationRestrictions {
+		restrictions = append(restrictions, authenticationRestrictionModel{
+			ClientSource:  strs(r.ClientSource),
+			ServerAddress: strs(r.ServerAddress),
+		})
+	}
+	return restrictions
+}
+
+// inheritedPrivilegeModels flattens the privileges the user holds through its roles. An empty db or collection
+// matches every name and is returned as null.
+func (u *userInfo) inheritedPrivilegeModels() []inheritedPrivilegeModel {
+	name := func(v *string) types.String {
+		if v == nil || *v == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(*v)
+	}
+
+	privileges := []inheritedPrivilegeModel{}
+	for _, p := range u.InheritedPrivileges {
+		actions := []types.String{}
+		for _, a := range p.Actions {
+			actions = append(actions, types.StringValue(a))
+		}
+		privileges = append(privileges, inheritedPrivilegeModel{
+			Resource: privilegeResourceModel{
+				DB:         name(p.Resource.DB),
+				Collection: name(p.Resource.Collection),
+				Cluster:    types.BoolValue(p.Resource.Cluster),
+			},
+			Actions: actions,
+		})
+	}
+	return privileges
+}
+
+// usersInfo looks up a single user in db, returning nil when the user does not exist.
+func usersInfo(ctx context.Context, db *mongo.Database, username string) (*userInfo, error) {
+	var result struct {
+		Users []userInfo `bson:"users"`
+	}
+	cmd := bson.D{
+		{Key: "usersInfo", Value: bson.D{
+			{Key: "user", Value: username},
+			{Key: "db", Value: db.Name()},
+		}},
+		{Key: "showAuthenticationRestrictions", Value: true},
+	}
+	if err := db.RunCommand(ctx, cmd).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Users) == 0 {
+		return nil, nil
+	}
+	return &result.Users[0], nil
+}
+
+// listUsers returns the users defined in database, or in every database when database is empty, together with
+// their inherited privileges. The server refuses showPrivileges when listing all users, so the users found are
+// looked up again by name.
+func listUsers(ctx context.Context, client *mongo.Client, database string) ([]userInfo, error) {
+	var result struct {
+		Users []userInfo `bson:"users"`
+	}
+	db, cmd := client.Database(database), bson.D{{Key: "usersInfo", Value: 1}}
+	if database == "" {
+		db, cmd = client.Database("admin"), bson.D{{Key: "usersInfo", Value: bson.D{{Key: "forAllDBs", Value: true}}}}
+	}
+	if err := db.RunCommand(ctx, cmd).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Users) == 0 {
+		return result.Users, nil
+	}
+
+	names := bson.A{}
+	for _, u := range result.Users {
+		names = append(names, bson.D{{Key: "user", Value: u.User}, {Key: "db", Value: u.DB}})
+	}
+	cmd = bson.D{
+		{Key: "usersInfo", Value: names},
+		{Key: "showPrivileges", Value: true},
+	}
+	result.Users = nil
+	if err := db.RunCommand(ctx, cmd).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.Users, nil
+}
+
+func isUserNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Code == userNotFoundCode || cmdErr.Name == "UserNotFound"
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = addressValidator{}
+
+// addressValidator validates that a string is an IP address or a CIDR range, the forms MongoDB accepts in
+// authentication restrictions.
+type addressValidator struct{}
+
+func (v addressValidator) Description(_ context.Context) string {
+	return "value must be an IP address or a CIDR range, e.g. '10.0.0.1' or '10.0.0.0/24'"
+}
+
+func (v addressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v addressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+
+	if net.ParseIP(value) != nil {
+		return
+	}
+	if _, _, err := net.ParseCIDR(value); err == nil {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid address",
+		fmt.Sprintf("%q is not an IP address or a CIDR range, e.g. '10.0.0.1' or '10.0.0.0/24'.", value),
+	)
+}
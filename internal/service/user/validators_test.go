@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAddressValidator(t *testing.T) {
+	cases := map[string]bool{
+		"10.0.0.1":        true,
+		"10.0.0.0/24":     true,
+		"::1":             true,
+		"2001:db8::/32":   true,
+		"10.0.0.0/33":     false,
+		"10.0.0.256":      false,
+		"db.example.com":  false,
+		"10.0.0.0/24,foo": false,
+	}
+
+	for value, valid := range cases {
+		t.Run(value, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("client_source"), ConfigValue: types.StringValue(value)}
+			var resp validator.StringResponse
+			addressValidator{}.ValidateString(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() == valid {
+				t.Errorf("valid = %t, want %t: %v", !resp.Diagnostics.HasError(), valid, resp.Diagnostics)
+			}
+		})
+	}
+}
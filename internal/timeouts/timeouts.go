@@ -0,0 +1,109 @@
+// Package timeouts provides the timeouts block that bounds how long a resource operation may run.
+package timeouts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Model is the timeouts block. Each value is a Go duration string such as "30m" or "1h30m".
+type Model struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// Defaults are the timeouts a resource uses for operations without a configured value.
+type Defaults struct {
+	Create time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// Block returns the timeouts block schema, documenting the resource's defaults.
+func Block(defaults Defaults) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Time limits for resource operations, as duration strings such as '30m' or '1h30m'.",
+		Attributes: map[string]schema.Attribute{
+			"create": durationAttribute("create", defaults.Create),
+			"update": durationAttribute("update", defaults.Update),
+			"delete": durationAttribute("delete", defaults.Delete),
+		},
+	}
+}
+
+func durationAttribute(operation string, def time.Duration) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: fmt.Sprintf("Time limit for %s. (Default: %s)", operation, def),
+		Validators: []validator.String{
+			durationValidator{},
+		},
+	}
+}
+
+// CreateContext returns ctx bounded by the create timeout.
+func (m *Model) CreateContext(ctx context.Context, defaults Defaults) (context.Context, context.CancelFunc) {
+	var v types.String
+	if m != nil {
+		v = m.Create
+	}
+	return context.WithTimeout(ctx, duration(v, defaults.Create))
+}
+
+// UpdateContext returns ctx bounded by the update timeout.
+func (m *Model) UpdateContext(ctx context.Context, defaults Defaults) (context.Context, context.CancelFunc) {
+	var v types.String
+	if m != nil {
+		v = m.Update
+	}
+	return context.WithTimeout(ctx, duration(v, defaults.Update))
+}
+
+// DeleteContext returns ctx bounded by the delete timeout.
+func (m *Model) DeleteContext(ctx context.Context, defaults Defaults) (context.Context, context.CancelFunc) {
+	var v types.String
+	if m != nil {
+		v = m.Delete
+	}
+	return context.WithTimeout(ctx, duration(v, defaults.Delete))
+}
+
+// duration parses v, falling back to def when it is unset. Invalid values are rejected by durationValidator.
+func duration(v types.String, def time.Duration) time.Duration {
+	if v.IsNull() || v.IsUnknown() {
+		return def
+	}
+	d, err := time.ParseDuration(v.ValueString())
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+// durationValidator checks that the value is a positive Go duration string.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration such as '30m' or '1h30m'"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration", fmt.Sprintf("%q: %s", req.ConfigValue.ValueString(), v.Description(ctx)))
+	}
+}